@@ -0,0 +1,159 @@
+package yid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wow-apps/youtube-id-go/internal/base62"
+)
+
+// ErrInvalidOption is returned by every Encoder method when New was given
+// an invalid option, such as an alphabet with duplicate characters.
+var ErrInvalidOption = errors.New("yid: invalid option")
+
+// Alphabet limits. Alphabets longer than 64 characters would overflow the
+// padUp offset and cannot be shuffled with a secure key.
+const (
+	MinAlphabetLength = 2
+	MaxAlphabetLength = 64
+)
+
+// DefaultAlphabet is the dictionary used when no alphabet is set: a-z, 0-9, A-Z.
+const DefaultAlphabet = base62.Dictionary
+
+// CrockfordAlphabet is Douglas Crockford's base32 alphabet. It has no
+// lower-case letters and omits I, L, O and U, so decoding is case-insensitive
+// and maps the look-alikes O to 0 and I and L to 1.
+const CrockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// WithAlphabet sets the characters IDs are written with, in digit order.
+// Encoding works in base len(alphabet). A secure key shuffles the alphabet;
+// sortable mode ignores it.
+//
+// An alphabet whose letters all have the same case is case-insensitive:
+// Decode accepts either case, and maps the confusables O/o to 0 and
+// I/i/L/l to 1 when the alphabet lacks the letter but has the digit.
+//
+// The alphabet must have between MinAlphabetLength and MaxAlphabetLength
+// distinct printable ASCII characters, excluding whitespace; otherwise every
+// method of the Encoder returns an error wrapping ErrInvalidOption.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithAlphabet(yid.CrockfordAlphabet))
+//	enc.Encode(12345)  // -> "C1S"
+//	enc.Decode("clS")  // -> 12345
+func WithAlphabet(alphabet string) Option {
+	return func(c *config) {
+		c.alphabet = alphabet
+	}
+}
+
+// validateAlphabet checks the rules documented on WithAlphabet.
+func validateAlphabet(alphabet string) error {
+	if len(alphabet) < MinAlphabetLength || len(alphabet) > MaxAlphabetLength {
+		return fmt.Errorf("%w: alphabet must have %d to %d characters, got %d",
+			ErrInvalidOption, MinAlphabetLength, MaxAlphabetLength, len(alphabet))
+	}
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c <= ' ' || c > '~' {
+			return fmt.Errorf("%w: alphabet character %q is not printable ASCII", ErrInvalidOption, c)
+		}
+		if seen[c] {
+			return fmt.Errorf("%w: alphabet has duplicate character %q", ErrInvalidOption, c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// letterCase describes the letters of a case-insensitive alphabet.
+type letterCase int
+
+const (
+	mixedCase letterCase = iota
+	upperCase
+	lowerCase
+)
+
+// caseOf returns upperCase or lowerCase if all letters of alphabet have that
+// case, and mixedCase otherwise (including alphabets without letters).
+func caseOf(alphabet string) letterCase {
+	hasUpper := strings.ContainsFunc(alphabet, func(r rune) bool { return 'A' <= r && r <= 'Z' })
+	hasLower := strings.ContainsFunc(alphabet, func(r rune) bool { return 'a' <= r && r <= 'z' })
+	switch {
+	case hasUpper && !hasLower:
+		return upperCase
+	case hasLower && !hasUpper:
+		return lowerCase
+	default:
+		return mixedCase
+	}
+}
+
+// confusables maps look-alike letters to the digits they are mistaken for.
+var confusables = map[byte]byte{'O': '0', 'I': '1', 'L': '1'}
+
+// checkAlphabet validates the configured alphabet and applies
+// WithSafeAlphabet. On error it resets the alphabet to the default, so that
+// New can still build a usable dictionary.
+func (c *config) checkAlphabet() error {
+	if c.safe && c.alphabet == "" {
+		c.alphabet = DefaultAlphabet
+	}
+	if c.alphabet == "" {
+		return nil
+	}
+	err := validateAlphabet(c.alphabet)
+	if err == nil && c.safe {
+		c.alphabet = SafeAlphabet(c.alphabet)
+		err = validateAlphabet(c.alphabet)
+	}
+	if err != nil {
+		c.alphabet = ""
+	}
+	return err
+}
+
+// newFold returns the byte mapping applied by normalization: for a
+// case-insensitive alphabet, the other case to the alphabet's case and
+// confusable letters missing from the alphabet to their digit; with
+// lookalikes, characters missing from the alphabet to the look-alike the
+// alphabet has (see WithLookalikes).
+func newFold(alphabet string, lc letterCase, lookalikes bool) [256]byte {
+	var fold [256]byte
+	for i := range fold {
+		fold[i] = byte(i)
+	}
+	if lc != mixedCase {
+		for c := 'a'; c <= 'z'; c++ {
+			upper, lower := byte(c-'a'+'A'), byte(c)
+			if lc == upperCase {
+				fold[lower] = upper
+			} else {
+				fold[upper] = lower
+			}
+		}
+		for letter, digit := range confusables {
+			lower := letter - 'A' + 'a'
+			if strings.IndexByte(alphabet, fold[letter]) >= 0 || strings.IndexByte(alphabet, digit) < 0 {
+				continue
+			}
+			fold[letter], fold[lower] = digit, digit
+		}
+	}
+	if lookalikes {
+		for i, c := range fold {
+			if strings.IndexByte(alphabet, c) >= 0 {
+				continue
+			}
+			if canonical, ok := lookalikeOf(alphabet, c); ok {
+				fold[i] = canonical
+			}
+		}
+	}
+	return fold
+}
@@ -0,0 +1,150 @@
+package yid_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestAlphabet_Roundtrip tests encoding and decoding with custom alphabets.
+func TestAlphabet_Roundtrip(t *testing.T) {
+	alphabets := map[string]string{
+		"binary":    "01",
+		"hex":       "0123456789abcdef",
+		"crockford": yid.CrockfordAlphabet,
+		"base64url": "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_",
+	}
+	numbers := []int64{0, 1, 31, 32, 12345, 1 << 40, math.MaxInt64}
+	for name, alphabet := range alphabets {
+		for _, opts := range [][]yid.Option{
+			{yid.WithAlphabet(alphabet)},
+			{yid.WithAlphabet(alphabet), yid.WithSecureKey("secret")},
+			{yid.WithAlphabet(alphabet), yid.WithSignature([]byte("hmac-secret"), 4)},
+		} {
+			enc := yid.New(opts...)
+			for _, n := range numbers {
+				encoded, err := enc.Encode(n)
+				if err != nil {
+					t.Fatalf("%s: Encode(%d): unexpected error: %v", name, n, err)
+				}
+				if strings.Trim(encoded, alphabet) != "" {
+					t.Errorf("%s: Encode(%d) = %q uses characters outside the alphabet", name, n, encoded)
+				}
+				if got, err := enc.Decode(encoded); err != nil || got != n {
+					t.Errorf("%s: Decode(%q) = %d, %v, want %d", name, encoded, got, err, n)
+				}
+			}
+		}
+	}
+}
+
+// TestAlphabet_Crockford tests the documented Crockford encodings and normalization.
+func TestAlphabet_Crockford(t *testing.T) {
+	enc := yid.New(yid.WithAlphabet(yid.CrockfordAlphabet))
+	encoded, _ := enc.Encode(12345)
+	if encoded != "C1S" {
+		t.Errorf("expected C1S, got %q", encoded)
+	}
+	for _, input := range []string{"C1S", "c1s", "clS", "CIS", "ciS"} {
+		if n, err := enc.Decode(input); err != nil || n != 12345 {
+			t.Errorf("Decode(%q) = %d, %v, want 12345", input, n, err)
+		}
+	}
+	for _, input := range []string{"o", "O"} {
+		if n, err := enc.Decode(input); err != nil || n != 0 {
+			t.Errorf("Decode(%q) = %d, %v, want 0", input, n, err)
+		}
+	}
+	if _, err := enc.Decode("C1U"); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter, got %v", err)
+	}
+	if !enc.Valid("c1s") || enc.Valid("0C1S") {
+		t.Error("expected Valid to accept normalized canonical input only")
+	}
+}
+
+// TestAlphabet_CaseSensitive tests that mixed-case alphabets are not folded.
+func TestAlphabet_CaseSensitive(t *testing.T) {
+	enc := yid.New(yid.WithAlphabet("0123456789abcdefABCDEF"))
+	a, _ := enc.Decode("a")
+	b, _ := enc.Decode("A")
+	if a == b {
+		t.Errorf("expected a and A to decode differently, both gave %d", a)
+	}
+	if _, err := enc.Decode("o"); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter, got %v", err)
+	}
+}
+
+// TestAlphabet_Default tests that the default alphabet leaves output unchanged.
+func TestAlphabet_Default(t *testing.T) {
+	for _, opts := range [][]yid.Option{nil, {yid.WithSecureKey("secret")}} {
+		want, _ := yid.New(opts...).Encode(12345)
+		got, _ := yid.New(append(opts, yid.WithAlphabet(yid.DefaultAlphabet))...).Encode(12345)
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+// TestAlphabet_Invalid tests that invalid alphabets make every method fail.
+func TestAlphabet_Invalid(t *testing.T) {
+	for _, alphabet := range []string{"a", "abca", "abc def", "abc\x00", "ab\xffc", strings.Repeat("x", 65)} {
+		enc := yid.New(yid.WithAlphabet(alphabet), yid.WithSignature([]byte("k"), 4))
+		if _, err := enc.Encode(1); !errors.Is(err, yid.ErrInvalidOption) {
+			t.Errorf("%q: Encode: expected ErrInvalidOption, got %v", alphabet, err)
+		}
+		if _, err := enc.Decode("a"); !errors.Is(err, yid.ErrInvalidOption) {
+			t.Errorf("%q: Decode: expected ErrInvalidOption, got %v", alphabet, err)
+		}
+		if err := enc.Validate("a"); !errors.Is(err, yid.ErrInvalidOption) {
+			t.Errorf("%q: Validate: expected ErrInvalidOption, got %v", alphabet, err)
+		}
+		if _, err := enc.EncodeExpiring(1, time.Unix(1000, 0)); !errors.Is(err, yid.ErrInvalidOption) {
+			t.Errorf("%q: EncodeExpiring: expected ErrInvalidOption, got %v", alphabet, err)
+		}
+		if _, err := enc.Random(8); !errors.Is(err, yid.ErrInvalidOption) {
+			t.Errorf("%q: Random: expected ErrInvalidOption, got %v", alphabet, err)
+		}
+	}
+}
+
+// TestAlphabet_Expiring tests that small alphabets use a longer expiry field.
+func TestAlphabet_Expiring(t *testing.T) {
+	enc := yid.New(yid.WithAlphabet("0123456789"), yid.WithSignature([]byte("hmac-secret"), 11))
+	expires := time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+	token, err := enc.EncodeExpiring(42, expires)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, err := enc.DecodeExpiring(token, time.Unix(0, 0)); err != nil || n != 42 {
+		t.Errorf("expected 42, got %d, %v", n, err)
+	}
+	if _, err := enc.DecodeExpiring(token, expires); !errors.Is(err, yid.ErrExpired) {
+		t.Errorf("expected ErrExpired, got %v", err)
+	}
+}
+
+// TestAlphabet_Pattern tests that the pattern matches exactly the canonical
+// encodings of a custom alphabet.
+func TestAlphabet_Pattern(t *testing.T) {
+	for _, opts := range [][]yid.Option{
+		{yid.WithAlphabet(yid.CrockfordAlphabet)},
+		{yid.WithAlphabet("0123456789"), yid.WithPadUp(3)},
+	} {
+		enc := yid.New(opts...)
+		re := regexp.MustCompile(enc.Pattern())
+		rng := rand.New(rand.NewSource(5))
+		for _, s := range candidates(enc, rng) {
+			if got, want := re.MatchString(s), canonical(enc, s); got != want {
+				t.Errorf("pattern match of %q = %t, want %t", s, got, want)
+			}
+		}
+	}
+}
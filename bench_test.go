@@ -0,0 +1,204 @@
+package yid_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// The benchmarks in this file form the suite that testdata/bench/baseline.txt
+// records and cmd/yid-bench compares against. Renaming a benchmark drops it
+// from the comparison until the baseline is regenerated.
+
+// benchSizes are numbers whose plain encodings have 1, 3, 6 and 11 characters.
+var benchSizes = []struct {
+	name string
+	n    int64
+}{
+	{"len=1", 7},
+	{"len=3", 12345},
+	{"len=6", 1 << 33},
+	{"len=11", 1 << 62},
+}
+
+// benchPadUps are the padUp settings of the suite.
+var benchPadUps = []int{0, 4, yid.MaxPadUp}
+
+// benchBatch is the number of IDs encoded or decoded per batch operation.
+const benchBatch = 1000
+
+// benchCases runs fn for every value size and padUp setting.
+func benchCases(b *testing.B, fn func(b *testing.B, n int64, opts []yid.Option)) {
+	for _, size := range benchSizes {
+		for _, padUp := range benchPadUps {
+			b.Run(fmt.Sprintf("%s/padUp=%d", size.name, padUp), func(b *testing.B) {
+				fn(b, size.n, []yid.Option{yid.WithPadUp(padUp)})
+			})
+		}
+	}
+}
+
+// BenchmarkSuite_ToAlphanumeric measures the package-level encoding function.
+func BenchmarkSuite_ToAlphanumeric(b *testing.B) {
+	benchCases(b, func(b *testing.B, n int64, opts []yid.Option) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			yid.ToAlphanumeric(n, opts...)
+		}
+	})
+}
+
+// BenchmarkSuite_ToNumeric measures the package-level decoding function.
+func BenchmarkSuite_ToNumeric(b *testing.B) {
+	benchCases(b, func(b *testing.B, n int64, opts []yid.Option) {
+		s, err := yid.ToAlphanumeric(n, opts...)
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			yid.ToNumeric(s, opts...)
+		}
+	})
+}
+
+// BenchmarkSuite_Encoder measures the Encoder methods with and without a
+// secure key and signature.
+func BenchmarkSuite_Encoder(b *testing.B) {
+	encoders := []struct {
+		name string
+		opts []yid.Option
+	}{
+		{"plain", nil},
+		{"secure", []yid.Option{yid.WithSecureKey("secret")}},
+		{"signed", []yid.Option{yid.WithSignature([]byte("hmac-secret"), 6)}},
+	}
+	for _, ec := range encoders {
+		enc := yid.New(ec.opts...)
+		s, err := enc.EncodeRaw(12345)
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		b.Run(ec.name+"/Encode", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				enc.Encode(12345)
+			}
+		})
+		b.Run(ec.name+"/EncodeRaw", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				enc.EncodeRaw(12345)
+			}
+		})
+		b.Run(ec.name+"/Decode", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				enc.Decode(s)
+			}
+		})
+		b.Run(ec.name+"/Valid", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				enc.Valid(s)
+			}
+		})
+	}
+
+	signed := yid.New(yid.WithSignature([]byte("hmac-secret"), 6))
+	expires := time.Unix(4102444800, 0)
+	token, err := signed.EncodeExpiring(12345, expires)
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	b.Run("signed/EncodeExpiring", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			signed.EncodeExpiring(12345, expires)
+		}
+	})
+	b.Run("signed/DecodeExpiring", func(b *testing.B) {
+		now := time.Unix(1700000000, 0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			signed.DecodeExpiring(token, now)
+		}
+	})
+	b.Run("plain/Random", func(b *testing.B) {
+		enc := yid.New()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc.Random(11)
+		}
+	})
+	b.Run("secure/New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			yid.New(yid.WithSecureKey("secret"))
+		}
+	})
+}
+
+// BenchmarkSuite_Batch measures encoding and decoding benchBatch sequential
+// IDs per operation with one Encoder, as when rendering a page of results.
+func BenchmarkSuite_Batch(b *testing.B) {
+	enc := yid.New(yid.WithSecureKey("secret"), yid.WithPadUp(4))
+	ids := make([]string, benchBatch)
+	for i := range ids {
+		ids[i], _ = enc.EncodeRaw(int64(1000000 + i))
+	}
+	b.Run("Encode", func(b *testing.B) {
+		out := make([]string, benchBatch)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := range out {
+				out[j], _ = enc.Encode(int64(1000000 + j))
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		out := make([]int64, benchBatch)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j, s := range ids {
+				out[j], _ = enc.Decode(s)
+			}
+		}
+	})
+}
+
+// BenchmarkSuite_BigNumber measures values at the top of the int64 range,
+// including the overflow checks that reject them.
+func BenchmarkSuite_BigNumber(b *testing.B) {
+	enc := yid.New()
+	padded := yid.New(yid.WithPadUp(yid.MaxPadUp))
+	maxID, _ := enc.EncodeRaw(math.MaxInt64)
+	b.Run("Encode/MaxInt64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc.Encode(math.MaxInt64)
+		}
+	})
+	b.Run("Decode/MaxInt64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc.Decode(maxID)
+		}
+	})
+	b.Run("Encode/Overflow", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			padded.Encode(math.MaxInt64)
+		}
+	})
+	b.Run("Decode/Overflow", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc.Decode(maxID + "a")
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package yid
+
+import (
+	"container/list"
+	"sync"
+)
+
+// encoderCacheSize bounds the number of encoders cached for ToAlphanumeric
+// and ToNumeric. Applications with more option sets should use New.
+const encoderCacheSize = 64
+
+// cacheKey identifies a configuration. It holds every config field that
+// affects encoding or decoding; configurations that cannot be keyed are
+// not cached (see config.cacheKey).
+type cacheKey struct {
+	padUp      int
+	secureKey  string
+	transform  Transform
+	sortable   bool
+	signKey    string
+	signChars  int
+	name       string
+	prefix     string
+	alphabet   string
+	groupSize  int
+	groupSep   string
+	safe       bool
+	lookalikes bool
+}
+
+// cacheKey returns the key of c. Configurations with observers, a random
+// source or a key error are not cached, since those cannot be compared.
+func (c *config) cacheKey() (cacheKey, bool) {
+	if len(c.observers) > 0 || c.randSource != nil || c.keyErr != nil {
+		return cacheKey{}, false
+	}
+	return cacheKey{
+		padUp:      c.padUp,
+		secureKey:  c.secureKey,
+		transform:  c.transform,
+		sortable:   c.sortable,
+		signKey:    string(c.signKey),
+		signChars:  c.signChars,
+		name:       c.name,
+		prefix:     c.prefix,
+		alphabet:   c.alphabet,
+		groupSize:  c.groupSize,
+		groupSep:   c.groupSep,
+		safe:       c.safe,
+		lookalikes: c.lookalikes,
+	}, true
+}
+
+// cacheEntry is an element of encoderCache.order.
+type cacheEntry struct {
+	key cacheKey
+	enc *Encoder
+}
+
+// encoderCache is a least-recently-used cache of encoders, safe for
+// concurrent use.
+type encoderCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // most recently used first
+	entries map[cacheKey]*list.Element
+}
+
+// newEncoderCache creates a cache holding at most size encoders.
+func newEncoderCache(size int) *encoderCache {
+	return &encoderCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+}
+
+// defaultCache serves ToAlphanumeric and ToNumeric.
+var defaultCache = newEncoderCache(encoderCacheSize)
+
+// cachedEncoder returns the encoder for opts from defaultCache.
+func cachedEncoder(opts []Option) *Encoder {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	key, ok := cfg.cacheKey()
+	if !ok {
+		return newEncoder(cfg)
+	}
+	return defaultCache.get(key, cfg)
+}
+
+// get returns the cached encoder for key, building it from cfg on a miss.
+// The encoder is built outside the lock; if two callers miss at once, the
+// first one stored wins.
+func (c *encoderCache) get(key cacheKey, cfg config) *Encoder {
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		c.mu.Unlock()
+		return el.Value.(*cacheEntry).enc
+	}
+	c.mu.Unlock()
+
+	enc := newEncoder(cfg)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*cacheEntry).enc
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key, enc})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return enc
+}
+
+// len returns the number of cached encoders.
+func (c *encoderCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
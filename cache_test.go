@@ -0,0 +1,121 @@
+package yid_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestCache_MatchesNew tests that cached package functions behave like New.
+func TestCache_MatchesNew(t *testing.T) {
+	optionSets := [][]yid.Option{
+		nil,
+		{yid.WithSecureKey("a")},
+		{yid.WithSecureKey("b")},
+		{yid.WithSecureKey("a"), yid.WithPadUp(4)},
+		{yid.WithSignature([]byte("k1"), 4)},
+		{yid.WithSignature([]byte("k2"), 4)},
+		{yid.WithAlphabet(yid.CrockfordAlphabet), yid.WithGrouping(2, "-")},
+		{yid.WithPrefix("usr_"), yid.WithTransform(yid.TransformUpper)},
+	}
+	// Twice, so that the second round is served from the cache.
+	for round := 0; round < 2; round++ {
+		for i, opts := range optionSets {
+			want, _ := yid.New(opts...).Encode(12345)
+			got, err := yid.ToAlphanumeric(12345, opts...)
+			if err != nil || got != want {
+				t.Errorf("round %d, set %d: ToAlphanumeric = %q, %v, want %q", round, i, got, err, want)
+			}
+			raw, _ := yid.New(opts...).EncodeRaw(12345)
+			if n, err := yid.ToNumeric(raw, opts...); err != nil || n != 12345 {
+				t.Errorf("round %d, set %d: ToNumeric(%q) = %d, %v", round, i, raw, n, err)
+			}
+		}
+	}
+}
+
+// TestCache_Bounded tests that the cache holds at most EncoderCacheSize encoders.
+func TestCache_Bounded(t *testing.T) {
+	for i := 0; i < 3*yid.EncoderCacheSize; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		want, _ := yid.New(yid.WithSecureKey(key)).Encode(int64(i))
+		if got, _ := yid.ToAlphanumeric(int64(i), yid.WithSecureKey(key)); got != want {
+			t.Fatalf("key %s: expected %q, got %q", key, want, got)
+		}
+	}
+	if n := yid.CachedEncoders(); n != yid.EncoderCacheSize {
+		t.Errorf("expected %d cached encoders, got %d", yid.EncoderCacheSize, n)
+	}
+}
+
+// TestCache_Observers tests that observers are notified on every call.
+func TestCache_Observers(t *testing.T) {
+	var obs recorder
+	for i := 0; i < 3; i++ {
+		yid.ToAlphanumeric(1, yid.WithObserver(&obs))
+	}
+	if len(obs.encodes) != 3 {
+		t.Errorf("expected 3 encode events, got %d", len(obs.encodes))
+	}
+}
+
+// TestCache_Concurrent tests concurrent use of the package functions; run with -race.
+func TestCache_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := yid.WithSecureKey(fmt.Sprintf("key-%d", (g+i)%(yid.EncoderCacheSize+8)))
+				encoded, err := yid.ToAlphanumeric(int64(i), key)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				if n, err := yid.ToNumeric(encoded, key); err != nil || n != int64(i) {
+					t.Errorf("ToNumeric(%q) = %d, %v, want %d", encoded, n, err, i)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// BenchmarkToAlphanumeric_SecureKey measures the cached package function.
+func BenchmarkToAlphanumeric_SecureKey(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		yid.ToAlphanumeric(int64(i), yid.WithSecureKey("secret"))
+	}
+}
+
+// BenchmarkToAlphanumeric_SecureKeyUncached measures building an encoder per
+// call, the path ToAlphanumeric took before encoders were cached.
+func BenchmarkToAlphanumeric_SecureKeyUncached(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		yid.New(yid.WithSecureKey("secret")).Encode(int64(i))
+	}
+}
+
+// BenchmarkToNumeric_SecureKey measures the cached package function.
+func BenchmarkToNumeric_SecureKey(b *testing.B) {
+	encoded, _ := yid.ToAlphanumeric(1<<40, yid.WithSecureKey("secret"))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		yid.ToNumeric(encoded, yid.WithSecureKey("secret"))
+	}
+}
+
+// BenchmarkToNumeric_SecureKeyUncached measures building an encoder per call.
+func BenchmarkToNumeric_SecureKeyUncached(b *testing.B) {
+	encoded, _ := yid.ToAlphanumeric(1<<40, yid.WithSecureKey("secret"))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		yid.New(yid.WithSecureKey("secret")).Decode(encoded)
+	}
+}
@@ -0,0 +1,55 @@
+// Command yid-alphabet reports the visually confusable characters of an
+// alphabet and prints the alphabet without them.
+//
+// Usage:
+//
+//	yid-alphabet [-alphabet 0123456789ABCDEFGHJKMNPQRSTVWXYZ]
+//
+// Without -alphabet, the default dictionary is analyzed. The exit status is
+// 1 if the alphabet is invalid for yid.WithAlphabet.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "yid-alphabet:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses arguments and writes the report of the alphabet to stdout.
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("yid-alphabet", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	alphabet := flags.String("alphabet", yid.DefaultAlphabet, "alphabet to analyze")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	r := yid.AnalyzeAlphabet(*alphabet)
+	pairs := make([]string, len(r.Confusables))
+	for i, p := range r.Confusables {
+		pairs[i] = p.String()
+	}
+	if len(pairs) == 0 {
+		pairs = append(pairs, "none")
+	}
+	fmt.Fprintf(stdout, "alphabet:         %s\n", *alphabet)
+	fmt.Fprintf(stdout, "length:           %d\n", r.Length)
+	fmt.Fprintf(stdout, "case-insensitive: %t\n", r.CaseInsensitive)
+	fmt.Fprintf(stdout, "confusable pairs: %s\n", strings.Join(pairs, " "))
+	fmt.Fprintf(stdout, "safe alphabet:    %s (%d characters)\n", r.Safe, len(r.Safe))
+	return r.Err
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestRun tests the report for the Crockford alphabet.
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"-alphabet", yid.CrockfordAlphabet}, &out, io.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{
+		"length:           32\n",
+		"case-insensitive: true\n",
+		"confusable pairs: 2/Z 5/S 6/G 8/B\n",
+		"safe alphabet:    0123456789ACDEFHJKMNPQRTVWXY (28 characters)\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("expected %q in output:\n%s", line, out.String())
+		}
+	}
+}
+
+// TestRun_Invalid tests that invalid alphabets are reported and fail.
+func TestRun_Invalid(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"-alphabet", "0123456789abcdef0"}, &out, io.Discard)
+	if !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+	if !strings.Contains(out.String(), "confusable pairs: none\n") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
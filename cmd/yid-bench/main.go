@@ -0,0 +1,275 @@
+// Command yid-bench compares benchmark results against a baseline and fails
+// when a benchmark allocates more, or with -time got slower, than the
+// thresholds allow.
+//
+// It reads the output of go test -bench -benchmem from the files given as
+// arguments, or from standard input. By default it gates allocs/op against
+// the checked-in baseline, which does not depend on the machine:
+//
+//	go test -run '^$' -bench . -benchmem -count 3 . ./internal/base62 | go run ./cmd/yid-bench
+//
+// Timings only compare between runs on the same machine, so ns/op is only
+// gated when -time is set, and the baseline should then be measured at the
+// base commit on the machine running the check:
+//
+//	git worktree add /tmp/yid-base main
+//	(cd /tmp/yid-base && go test -run '^$' -bench . -benchmem -count 6 . ./internal/base62) > old.txt
+//	go test -run '^$' -bench . -benchmem -count 6 . ./internal/base62 > new.txt
+//	go run ./cmd/yid-bench -baseline old.txt -time 0.25 new.txt
+//
+// With -count, the fastest run of each benchmark is compared, which keeps
+// noise from failing the check. Benchmarks missing from either side are
+// reported but do not fail it.
+//
+// Usage:
+//
+//	yid-bench [-baseline testdata/bench/baseline.txt] [-time 0] [-allocs 0] [file ...]
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// DefaultBaseline is the checked-in baseline, relative to the module root.
+const DefaultBaseline = "testdata/bench/baseline.txt"
+
+// errRegressed is returned when at least one benchmark regressed.
+var errRegressed = errors.New("benchmarks regressed")
+
+// procsSuffix matches the GOMAXPROCS suffix go test appends to benchmark names.
+var procsSuffix = regexp.MustCompile(`-\d+$`)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "yid-bench:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses arguments, reads both result sets and writes the comparison.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("yid-bench", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	baselinePath := flags.String("baseline", DefaultBaseline, "file with the baseline results")
+	timeThreshold := flags.Float64("time", 0, "allowed ns/op increase as a fraction of the baseline; 0 reports ns/op without gating it")
+	allocsThreshold := flags.Float64("allocs", 0, "allowed allocs/op increase as a fraction of the baseline")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	baseline, err := parseFile(*baselinePath)
+	if err != nil {
+		return err
+	}
+	var current map[string]result
+	if flags.NArg() == 0 {
+		current, err = parse(stdin)
+	} else {
+		current = make(map[string]result)
+		for _, path := range flags.Args() {
+			var more map[string]result
+			if more, err = parseFile(path); err != nil {
+				break
+			}
+			merge(current, more)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(current) == 0 {
+		return errors.New("no benchmark results in input")
+	}
+
+	rows := compare(baseline, current, *timeThreshold, *allocsThreshold)
+	write(stdout, rows)
+	regressed := 0
+	for _, r := range rows {
+		if r.status == statusRegressed {
+			regressed++
+		}
+	}
+	if regressed > 0 {
+		return fmt.Errorf("%d %w", regressed, errRegressed)
+	}
+	return nil
+}
+
+// result is the best measurement of one benchmark.
+type result struct {
+	nsPerOp     float64
+	allocsPerOp float64
+	hasAllocs   bool
+}
+
+// parseFile parses the benchmark results in the file at path.
+func parseFile(path string) (map[string]result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+// parse reads go test -bench output. Results are keyed by package and
+// benchmark name without the GOMAXPROCS suffix; repeated runs keep the
+// lowest ns/op and allocs/op.
+func parse(r io.Reader) (map[string]result, error) {
+	results := make(map[string]result)
+	pkg := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if p, ok := strings.CutPrefix(line, "pkg: "); ok {
+			pkg = strings.TrimSpace(p)
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		var res result
+		found := false
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: bad value %q", fields[0], fields[i])
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				res.nsPerOp, found = v, true
+			case "allocs/op":
+				res.allocsPerOp, res.hasAllocs = v, true
+			}
+		}
+		if !found {
+			continue
+		}
+		merge(results, map[string]result{key(pkg, fields[0]): res})
+	}
+	return results, scanner.Err()
+}
+
+// key returns the name results are keyed by.
+func key(pkg, name string) string {
+	name = procsSuffix.ReplaceAllString(name, "")
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
+}
+
+// merge adds the results of src to dst, keeping the best of both.
+func merge(dst, src map[string]result) {
+	for name, res := range src {
+		prev, ok := dst[name]
+		if !ok {
+			dst[name] = res
+			continue
+		}
+		prev.nsPerOp = min(prev.nsPerOp, res.nsPerOp)
+		if res.hasAllocs {
+			if !prev.hasAllocs || res.allocsPerOp < prev.allocsPerOp {
+				prev.allocsPerOp = res.allocsPerOp
+			}
+			prev.hasAllocs = true
+		}
+		dst[name] = prev
+	}
+}
+
+// Comparison statuses.
+const (
+	statusOK        = "ok"
+	statusRegressed = "REGRESSED"
+	statusNew       = "new"
+	statusMissing   = "missing"
+)
+
+// row is the comparison of one benchmark.
+type row struct {
+	name          string
+	base, current result
+	status        string
+}
+
+// compare returns one row per benchmark in either set, sorted by name.
+// A timeThreshold of 0 disables the ns/op check.
+func compare(baseline, current map[string]result, timeThreshold, allocsThreshold float64) []row {
+	names := make(map[string]bool)
+	for name := range baseline {
+		names[name] = true
+	}
+	for name := range current {
+		names[name] = true
+	}
+
+	rows := make([]row, 0, len(names))
+	for name := range names {
+		base, inBase := baseline[name]
+		cur, inCurrent := current[name]
+		r := row{name: name, base: base, current: cur, status: statusOK}
+		switch {
+		case !inBase:
+			r.status = statusNew
+		case !inCurrent:
+			r.status = statusMissing
+		case timeThreshold > 0 && cur.nsPerOp > base.nsPerOp*(1+timeThreshold):
+			r.status = statusRegressed
+		case base.hasAllocs && cur.hasAllocs && cur.allocsPerOp > base.allocsPerOp*(1+allocsThreshold):
+			r.status = statusRegressed
+		}
+		rows = append(rows, r)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].name < rows[j].name })
+	return rows
+}
+
+// write prints rows as an aligned table.
+func write(w io.Writer, rows []row) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "benchmark\tbase ns/op\tns/op\tdelta\tbase allocs\tallocs\tstatus\t")
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", r.name,
+			ns(r.base, r.status != statusNew), ns(r.current, r.status != statusMissing), delta(r),
+			allocs(r.base, r.status != statusNew), allocs(r.current, r.status != statusMissing), r.status)
+	}
+	tw.Flush()
+}
+
+// ns formats the ns/op of res, or "-" if it was not measured.
+func ns(res result, measured bool) string {
+	if !measured {
+		return "-"
+	}
+	return strconv.FormatFloat(res.nsPerOp, 'f', -1, 64)
+}
+
+// allocs formats the allocs/op of res, or "-" if it was not measured.
+func allocs(res result, measured bool) string {
+	if !measured || !res.hasAllocs {
+		return "-"
+	}
+	return strconv.FormatFloat(res.allocsPerOp, 'f', -1, 64)
+}
+
+// delta formats the ns/op change of r as a percentage.
+func delta(r row) string {
+	if r.status == statusNew || r.status == statusMissing || r.base.nsPerOp == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", (r.current.nsPerOp/r.base.nsPerOp-1)*100)
+}
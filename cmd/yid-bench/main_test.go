@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const baselineOutput = `goos: linux
+goarch: amd64
+pkg: example.com/p
+BenchmarkFast-8     	 1000000	       100 ns/op	       0 B/op	       0 allocs/op
+BenchmarkFast-8     	 1000000	        90 ns/op	       0 B/op	       0 allocs/op
+BenchmarkSlow/n=1-8 	  100000	      1000 ns/op	      64 B/op	       2 allocs/op
+BenchmarkGone-8     	  100000	      1000 ns/op
+PASS
+ok  	example.com/p	1.234s
+`
+
+// TestParse tests that repeated runs keep the best result and names are normalized.
+func TestParse(t *testing.T) {
+	results, err := parse(strings.NewReader(baselineOutput))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]result{
+		"example.com/p.BenchmarkFast":     {nsPerOp: 90, allocsPerOp: 0, hasAllocs: true},
+		"example.com/p.BenchmarkSlow/n=1": {nsPerOp: 1000, allocsPerOp: 2, hasAllocs: true},
+		"example.com/p.BenchmarkGone":     {nsPerOp: 1000},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %v", len(expected), results)
+	}
+	for name, want := range expected {
+		if got := results[name]; got != want {
+			t.Errorf("%s: expected %+v, got %+v", name, want, got)
+		}
+	}
+}
+
+// TestCompare tests the status of each kind of change.
+func TestCompare(t *testing.T) {
+	baseline := map[string]result{
+		"same":    {nsPerOp: 100, allocsPerOp: 1, hasAllocs: true},
+		"slower":  {nsPerOp: 100, allocsPerOp: 1, hasAllocs: true},
+		"noisy":   {nsPerOp: 100, allocsPerOp: 1, hasAllocs: true},
+		"allocs":  {nsPerOp: 100, allocsPerOp: 0, hasAllocs: true},
+		"missing": {nsPerOp: 100},
+	}
+	current := map[string]result{
+		"same":   {nsPerOp: 80, allocsPerOp: 1, hasAllocs: true},
+		"slower": {nsPerOp: 130, allocsPerOp: 1, hasAllocs: true},
+		"noisy":  {nsPerOp: 120, allocsPerOp: 1, hasAllocs: true},
+		"allocs": {nsPerOp: 100, allocsPerOp: 1, hasAllocs: true},
+		"new":    {nsPerOp: 100},
+	}
+	expected := map[string]string{
+		"same":    statusOK,
+		"slower":  statusRegressed,
+		"noisy":   statusOK,
+		"allocs":  statusRegressed,
+		"missing": statusMissing,
+		"new":     statusNew,
+	}
+	rows := compare(baseline, current, 0.25, 0)
+	if len(rows) != len(expected) {
+		t.Fatalf("expected %d rows, got %d", len(expected), len(rows))
+	}
+	for i, r := range rows {
+		if i > 0 && rows[i-1].name >= r.name {
+			t.Errorf("rows not sorted: %q before %q", rows[i-1].name, r.name)
+		}
+		if r.status != expected[r.name] {
+			t.Errorf("%s: expected %s, got %s", r.name, expected[r.name], r.status)
+		}
+	}
+
+	// Without a time threshold only allocations are gated.
+	expected["slower"] = statusOK
+	for _, r := range compare(baseline, current, 0, 0) {
+		if r.status != expected[r.name] {
+			t.Errorf("allocs only: %s: expected %s, got %s", r.name, expected[r.name], r.status)
+		}
+	}
+}
+
+// TestRun tests the exit status for passing and regressed input.
+func TestRun(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), "baseline.txt")
+	if err := os.WriteFile(baseline, []byte(baselineOutput), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"-baseline", baseline}, strings.NewReader(baselineOutput), &out, io.Discard); err != nil {
+		t.Errorf("unexpected error: %v\n%s", err, out.String())
+	}
+
+	slower := strings.ReplaceAll(baselineOutput, "1000 ns/op", "2000 ns/op")
+	out.Reset()
+	if err := run([]string{"-baseline", baseline}, strings.NewReader(slower), &out, io.Discard); err != nil {
+		t.Errorf("expected timings not to be gated by default, got %v", err)
+	}
+	if !strings.Contains(out.String(), "+100.0%") {
+		t.Errorf("expected the ns/op change to be reported:\n%s", out.String())
+	}
+
+	out.Reset()
+	err := run([]string{"-baseline", baseline, "-time", "0.25"}, strings.NewReader(slower), &out, io.Discard)
+	if !errors.Is(err, errRegressed) {
+		t.Errorf("expected errRegressed, got %v", err)
+	}
+	if !strings.Contains(out.String(), statusRegressed) {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	if err := run([]string{"-baseline", baseline, "-time", "1.5"}, strings.NewReader(slower), io.Discard, io.Discard); err != nil {
+		t.Errorf("unexpected error with a higher threshold: %v", err)
+	}
+
+	moreAllocs := strings.ReplaceAll(baselineOutput, "2 allocs/op", "3 allocs/op")
+	if err := run([]string{"-baseline", baseline}, strings.NewReader(moreAllocs), io.Discard, io.Discard); !errors.Is(err, errRegressed) {
+		t.Errorf("expected errRegressed for more allocations, got %v", err)
+	}
+	if err := run([]string{"-baseline", baseline}, strings.NewReader("PASS\n"), io.Discard, io.Discard); err == nil {
+		t.Error("expected an error for input without results")
+	}
+}
+
+// TestBaseline tests that the checked-in baseline parses and covers the suite.
+func TestBaseline(t *testing.T) {
+	results, err := parseFile(filepath.Join("..", "..", DefaultBaseline))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{
+		"github.com/wow-apps/youtube-id-go.BenchmarkSuite_ToAlphanumeric/len=3/padUp=0",
+		"github.com/wow-apps/youtube-id-go.BenchmarkSuite_ToNumeric/len=11/padUp=11",
+		"github.com/wow-apps/youtube-id-go.BenchmarkSuite_Encoder/plain/Decode",
+		"github.com/wow-apps/youtube-id-go.BenchmarkSuite_Batch/Encode",
+		"github.com/wow-apps/youtube-id-go.BenchmarkSuite_BigNumber/Encode/MaxInt64",
+		"github.com/wow-apps/youtube-id-go/internal/base62.BenchmarkSecureDictionary",
+	} {
+		if _, ok := results[name]; !ok {
+			t.Errorf("baseline lacks %s", name)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Package example holds ID types generated by yid-gen. Its tests exercise
+// the generated code, and the yid-gen tests check that yid_ids.go is up to date.
+package example
+
+//go:generate go run github.com/wow-apps/youtube-id-go/cmd/yid-gen
+
+// UserID identifies a user.
+//
+//yid:id name=user prefix=usr_ pad=4
+type UserID int64
+
+// OrderID identifies an order.
+//
+//yid:id name=order
+type OrderID int64
+
+// Count is not an ID and is ignored by yid-gen.
+type Count int64
@@ -0,0 +1,134 @@
+package example_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/cmd/yid-gen/internal/example"
+)
+
+// Compile-time checks of the generated method sets.
+var (
+	_ encoding.TextMarshaler   = example.UserID(0)
+	_ encoding.TextUnmarshaler = (*example.UserID)(nil)
+	_ json.Marshaler           = example.UserID(0)
+	_ json.Unmarshaler         = (*example.UserID)(nil)
+	_ sql.Scanner              = (*example.UserID)(nil)
+	_ driver.Valuer            = example.UserID(0)
+)
+
+var registry = yid.NewRegistry([]byte("master-secret"))
+
+func init() {
+	if err := example.RegisterIDs(registry); err != nil {
+		panic(err)
+	}
+}
+
+// TestGenerated_Encode tests that IDs use their derived encoder, prefix and padding.
+func TestGenerated_Encode(t *testing.T) {
+	enc, ok := registry.Lookup("user")
+	if !ok {
+		t.Fatal("expected user encoder to be registered")
+	}
+	expected, _ := enc.Encode(12345)
+
+	s := example.UserID(12345).String()
+	if s != expected {
+		t.Errorf("expected %q, got %q", expected, s)
+	}
+	if !strings.HasPrefix(s, "usr_") || len(s) != len("usr_")+4 {
+		t.Errorf("expected prefix and padding, got %q", s)
+	}
+
+	order := example.OrderID(12345).String()
+	if order == s || strings.HasPrefix(order, "usr_") {
+		t.Errorf("expected order IDs to use their own encoder, got %q", order)
+	}
+}
+
+// TestGenerated_Parse tests parsing and its errors.
+func TestGenerated_Parse(t *testing.T) {
+	s := example.UserID(42).String()
+	id, err := example.ParseUserID(s)
+	if err != nil || id != 42 {
+		t.Errorf("expected 42, got %d, %v", id, err)
+	}
+	if _, err := example.ParseUserID("ord_abcd"); !errors.Is(err, yid.ErrInvalidPrefix) {
+		t.Errorf("expected ErrInvalidPrefix, got %v", err)
+	}
+	if s := example.UserID(-1).String(); !strings.HasPrefix(s, "!INVALID(") {
+		t.Errorf("expected placeholder, got %q", s)
+	}
+}
+
+// TestGenerated_JSON tests JSON round trips inside a struct.
+func TestGenerated_JSON(t *testing.T) {
+	type payload struct {
+		User  example.UserID  `json:"user"`
+		Order example.OrderID `json:"order"`
+	}
+	in := payload{User: 7, Order: 8}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"user":"usr_`) {
+		t.Errorf("expected encoded user, got %s", data)
+	}
+
+	var out payload
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != in {
+		t.Errorf("expected %+v, got %+v", in, out)
+	}
+	if err := json.Unmarshal([]byte(`{"user": 7}`), &out); err == nil {
+		t.Error("expected error for numeric JSON")
+	}
+	if _, err := json.Marshal(payload{User: -1}); !errors.Is(err, yid.ErrNegativeNumber) {
+		t.Errorf("expected ErrNegativeNumber, got %v", err)
+	}
+}
+
+// TestGenerated_SQL tests Scan and Value.
+func TestGenerated_SQL(t *testing.T) {
+	v, err := example.UserID(42).Value()
+	if err != nil || v != int64(42) {
+		t.Errorf("expected 42, got %v, %v", v, err)
+	}
+
+	tests := []struct {
+		src      any
+		expected example.UserID
+		wantErr  bool
+	}{
+		{int64(42), 42, false},
+		{[]byte("42"), 42, false},
+		{"42", 42, false},
+		{"usr_abcd", 0, true},
+		{nil, 0, true},
+		{4.2, 0, true},
+	}
+	for _, tt := range tests {
+		var id example.UserID
+		err := id.Scan(tt.src)
+		if (err != nil) != tt.wantErr || id != tt.expected {
+			t.Errorf("Scan(%v) = %d, %v", tt.src, id, err)
+		}
+	}
+}
+
+// TestRegisterIDs_NoMasterSecret tests that registration errors are returned.
+func TestRegisterIDs_NoMasterSecret(t *testing.T) {
+	if err := example.RegisterIDs(yid.NewRegistry(nil)); !errors.Is(err, yid.ErrNoMasterSecret) {
+		t.Errorf("expected ErrNoMasterSecret, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package example
+
+import (
+	"errors"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestUnregistered tests that IDs fail cleanly before RegisterIDs is called.
+func TestUnregistered(t *testing.T) {
+	saved := userIDEncoder.Swap(nil)
+	defer userIDEncoder.Store(saved)
+
+	if _, err := UserID(1).Encode(); !errors.Is(err, yid.ErrUnknownEncoder) {
+		t.Errorf("Encode: expected ErrUnknownEncoder, got %v", err)
+	}
+	if _, err := ParseUserID("usr_abcd"); !errors.Is(err, yid.ErrUnknownEncoder) {
+		t.Errorf("ParseUserID: expected ErrUnknownEncoder, got %v", err)
+	}
+	if _, err := UserID(1).MarshalText(); !errors.Is(err, yid.ErrUnknownEncoder) {
+		t.Errorf("MarshalText: expected ErrUnknownEncoder, got %v", err)
+	}
+}
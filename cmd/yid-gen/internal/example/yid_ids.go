@@ -0,0 +1,249 @@
+// Code generated by yid-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// RegisterIDs derives the encoders of this package's ID types from r.
+// Call it once at startup, before any ID is encoded or decoded.
+func RegisterIDs(r *yid.Registry) error {
+	enc0, err := r.Derive("order")
+	if err != nil {
+		return fmt.Errorf("register OrderID: %w", err)
+	}
+	enc1, err := r.Derive("user", yid.WithPrefix("usr_"), yid.WithPadUp(4))
+	if err != nil {
+		return fmt.Errorf("register UserID: %w", err)
+	}
+	orderIDEncoder.Store(enc0)
+	userIDEncoder.Store(enc1)
+	return nil
+}
+
+// orderIDEncoder is set by RegisterIDs.
+var orderIDEncoder atomic.Pointer[yid.Encoder]
+
+// orderIDEnc returns the encoder of OrderID.
+func orderIDEnc() (*yid.Encoder, error) {
+	enc := orderIDEncoder.Load()
+	if enc == nil {
+		return nil, fmt.Errorf("%w: %q (call RegisterIDs)", yid.ErrUnknownEncoder, "order")
+	}
+	return enc, nil
+}
+
+// ParseOrderID decodes an encoded OrderID.
+func ParseOrderID(s string) (OrderID, error) {
+	enc, err := orderIDEnc()
+	if err != nil {
+		return 0, err
+	}
+	n, err := enc.Decode(s)
+	if err != nil {
+		return 0, fmt.Errorf("parse OrderID %q: %w", s, err)
+	}
+	return OrderID(n), nil
+}
+
+// Encode returns the encoded form of id.
+func (id OrderID) Encode() (string, error) {
+	enc, err := orderIDEnc()
+	if err != nil {
+		return "", err
+	}
+	return enc.Encode(int64(id))
+}
+
+// String returns the encoded form of id, or a placeholder if it cannot be encoded.
+func (id OrderID) String() string {
+	s, err := id.Encode()
+	if err != nil {
+		return fmt.Sprintf("!INVALID(%v)", err)
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id OrderID) MarshalText() ([]byte, error) {
+	s, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *OrderID) UnmarshalText(text []byte) error {
+	parsed, err := ParseOrderID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Encoded IDs need no escaping.
+func (id OrderID) MarshalJSON() ([]byte, error) {
+	s, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`"` + s + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *OrderID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("parse OrderID: %w", err)
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner. Databases store the number.
+func (id *OrderID) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*id = OrderID(v)
+		return nil
+	case []byte:
+		return id.scanString(string(v))
+	case string:
+		return id.scanString(v)
+	default:
+		return fmt.Errorf("scan OrderID: unsupported type %T", src)
+	}
+}
+
+// scanString parses a number returned as text by the driver.
+func (id *OrderID) scanString(s string) error {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("scan OrderID: %w", err)
+	}
+	*id = OrderID(n)
+	return nil
+}
+
+// Value implements driver.Valuer. Databases store the number.
+func (id OrderID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+
+// userIDEncoder is set by RegisterIDs.
+var userIDEncoder atomic.Pointer[yid.Encoder]
+
+// userIDEnc returns the encoder of UserID.
+func userIDEnc() (*yid.Encoder, error) {
+	enc := userIDEncoder.Load()
+	if enc == nil {
+		return nil, fmt.Errorf("%w: %q (call RegisterIDs)", yid.ErrUnknownEncoder, "user")
+	}
+	return enc, nil
+}
+
+// ParseUserID decodes an encoded UserID.
+func ParseUserID(s string) (UserID, error) {
+	enc, err := userIDEnc()
+	if err != nil {
+		return 0, err
+	}
+	n, err := enc.Decode(s)
+	if err != nil {
+		return 0, fmt.Errorf("parse UserID %q: %w", s, err)
+	}
+	return UserID(n), nil
+}
+
+// Encode returns the encoded form of id.
+func (id UserID) Encode() (string, error) {
+	enc, err := userIDEnc()
+	if err != nil {
+		return "", err
+	}
+	return enc.Encode(int64(id))
+}
+
+// String returns the encoded form of id, or a placeholder if it cannot be encoded.
+func (id UserID) String() string {
+	s, err := id.Encode()
+	if err != nil {
+		return fmt.Sprintf("!INVALID(%v)", err)
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id UserID) MarshalText() ([]byte, error) {
+	s, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *UserID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUserID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Encoded IDs need no escaping.
+func (id UserID) MarshalJSON() ([]byte, error) {
+	s, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`"` + s + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *UserID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("parse UserID: %w", err)
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner. Databases store the number.
+func (id *UserID) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*id = UserID(v)
+		return nil
+	case []byte:
+		return id.scanString(string(v))
+	case string:
+		return id.scanString(v)
+	default:
+		return fmt.Errorf("scan UserID: unsupported type %T", src)
+	}
+}
+
+// scanString parses a number returned as text by the driver.
+func (id *UserID) scanString(s string) error {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("scan UserID: %w", err)
+	}
+	*id = UserID(n)
+	return nil
+}
+
+// Value implements driver.Valuer. Databases store the number.
+func (id UserID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
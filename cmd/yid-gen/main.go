@@ -0,0 +1,378 @@
+// Command yid-gen generates strongly typed ID types backed by yid encoders.
+//
+// It scans the Go files of a package for integer types annotated with a
+// //yid:id directive and writes their methods to a single file:
+//
+//	//yid:id name=user prefix=usr_ pad=4
+//	type UserID int64
+//
+// For each type T, the generated file declares String, MarshalText,
+// UnmarshalText, MarshalJSON, UnmarshalJSON, Scan and Value methods and a
+// ParseT function, plus one RegisterIDs function for the package:
+//
+//	func RegisterIDs(r *yid.Registry) error
+//
+// RegisterIDs derives each type's encoder with r.Derive(name, ...), so name
+// is both the registry name and the key derivation context. Call it once at
+// startup; until then the methods return yid.ErrUnknownEncoder. The
+// generated code does not use reflection.
+//
+// Directive fields:
+//
+//	name    registry name and key context (required)
+//	prefix  prefix added to encoded IDs, see yid.WithPrefix
+//	pad     minimum length, see yid.WithPadUp
+//
+// Usage:
+//
+//	//go:generate go run github.com/wow-apps/youtube-id-go/cmd/yid-gen
+//
+//	yid-gen [-output yid_ids.go] [dir]
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// directive marks an ID type declaration.
+const directive = "//yid:id"
+
+// DefaultOutput is the name of the generated file.
+const DefaultOutput = "yid_ids.go"
+
+// validPrefix restricts prefixes to characters that need no quoting in JSON or URLs.
+var validPrefix = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// errNoTypes is returned when a package has no annotated types.
+var errNoTypes = errors.New("no //yid:id types found")
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "yid-gen:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses arguments, generates the file and writes it next to the sources.
+func run(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("yid-gen", flag.ContinueOnError)
+	flags.SetOutput(output)
+	out := flags.String("output", DefaultOutput, "name of the generated file, relative to the package directory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+
+	pkg, err := parsePackage(dir, filepath.Base(*out))
+	if err != nil {
+		return err
+	}
+	src, err := generate(pkg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, *out), src, 0o644)
+}
+
+// idType is an annotated type declaration.
+type idType struct {
+	Type   string
+	Name   string
+	Prefix string
+	PadUp  int
+}
+
+// pkgInfo is the input of the template.
+type pkgInfo struct {
+	Name  string
+	Types []idType
+}
+
+// parsePackage reads the non-test Go files in dir, except skip, and returns
+// the annotated types in name order.
+func parsePackage(dir, skip string) (*pkgInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	pkg := &pkgInfo{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == skip || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.Name == "" {
+			pkg.Name = file.Name.Name
+		}
+		types, err := collect(fset, file)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Types = append(pkg.Types, types...)
+	}
+	if len(pkg.Types) == 0 {
+		return nil, fmt.Errorf("%s: %w", dir, errNoTypes)
+	}
+	sort.Slice(pkg.Types, func(i, j int) bool { return pkg.Types[i].Type < pkg.Types[j].Type })
+	return pkg, nil
+}
+
+// collect returns the annotated types declared in file.
+func collect(fset *token.FileSet, file *ast.File) ([]idType, error) {
+	var types []idType
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			doc := ts.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			line, ok := findDirective(doc)
+			if !ok {
+				continue
+			}
+			pos := fset.Position(ts.Pos())
+			if ident, ok := ts.Type.(*ast.Ident); !ok || ident.Name != "int64" || ts.Assign.IsValid() {
+				return nil, fmt.Errorf("%s: %s: //yid:id types must be defined as int64", pos, ts.Name.Name)
+			}
+			t, err := parseDirective(line)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", pos, ts.Name.Name, err)
+			}
+			t.Type = ts.Name.Name
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+// findDirective returns the text after the //yid:id directive in doc.
+func findDirective(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, c := range doc.List {
+		if rest, ok := strings.CutPrefix(c.Text, directive); ok && (rest == "" || rest[0] == ' ') {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// parseDirective parses the key=value fields of a directive.
+func parseDirective(line string) (idType, error) {
+	var t idType
+	for _, field := range strings.Fields(line) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return t, fmt.Errorf("malformed field %q, want key=value", field)
+		}
+		switch key {
+		case "name":
+			t.Name = value
+		case "prefix":
+			if !validPrefix.MatchString(value) {
+				return t, fmt.Errorf("prefix %q may only contain letters, digits, '_' and '-'", value)
+			}
+			t.Prefix = value
+		case "pad":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > yid.MaxPadUp {
+				return t, fmt.Errorf("pad %q must be an integer between 0 and %d", value, yid.MaxPadUp)
+			}
+			t.PadUp = n
+		default:
+			return t, fmt.Errorf("unknown field %q", key)
+		}
+	}
+	if t.Name == "" {
+		return t, errors.New("missing name")
+	}
+	return t, nil
+}
+
+// generate renders and formats the generated file.
+func generate(pkg *pkgInfo) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, pkg); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"lowerFirst": func(s string) string { return strings.ToLower(s[:1]) + s[1:] },
+}).Parse(`// Code generated by yid-gen. DO NOT EDIT.
+
+package {{.Name}}
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// RegisterIDs derives the encoders of this package's ID types from r.
+// Call it once at startup, before any ID is encoded or decoded.
+func RegisterIDs(r *yid.Registry) error {
+{{- range $i, $t := .Types}}
+	enc{{$i}}, err := r.Derive({{printf "%q" .Name}}{{if .Prefix}}, yid.WithPrefix({{printf "%q" .Prefix}}){{end}}{{if .PadUp}}, yid.WithPadUp({{.PadUp}}){{end}})
+	if err != nil {
+		return fmt.Errorf("register {{.Type}}: %w", err)
+	}
+{{- end}}
+{{range $i, $t := .Types}}	{{lowerFirst $t.Type}}Encoder.Store(enc{{$i}})
+{{end -}}
+	return nil
+}
+{{range .Types}}
+{{$enc := printf "%sEncoder" (lowerFirst .Type)}}
+// {{$enc}} is set by RegisterIDs.
+var {{$enc}} atomic.Pointer[yid.Encoder]
+
+// {{lowerFirst .Type}}Enc returns the encoder of {{.Type}}.
+func {{lowerFirst .Type}}Enc() (*yid.Encoder, error) {
+	enc := {{$enc}}.Load()
+	if enc == nil {
+		return nil, fmt.Errorf("%w: %q (call RegisterIDs)", yid.ErrUnknownEncoder, {{printf "%q" .Name}})
+	}
+	return enc, nil
+}
+
+// Parse{{.Type}} decodes an encoded {{.Type}}.
+func Parse{{.Type}}(s string) ({{.Type}}, error) {
+	enc, err := {{lowerFirst .Type}}Enc()
+	if err != nil {
+		return 0, err
+	}
+	n, err := enc.Decode(s)
+	if err != nil {
+		return 0, fmt.Errorf("parse {{.Type}} %q: %w", s, err)
+	}
+	return {{.Type}}(n), nil
+}
+
+// Encode returns the encoded form of id.
+func (id {{.Type}}) Encode() (string, error) {
+	enc, err := {{lowerFirst .Type}}Enc()
+	if err != nil {
+		return "", err
+	}
+	return enc.Encode(int64(id))
+}
+
+// String returns the encoded form of id, or a placeholder if it cannot be encoded.
+func (id {{.Type}}) String() string {
+	s, err := id.Encode()
+	if err != nil {
+		return fmt.Sprintf("!INVALID(%v)", err)
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id {{.Type}}) MarshalText() ([]byte, error) {
+	s, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *{{.Type}}) UnmarshalText(text []byte) error {
+	parsed, err := Parse{{.Type}}(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Encoded IDs need no escaping.
+func (id {{.Type}}) MarshalJSON() ([]byte, error) {
+	s, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(` + "`\"`" + ` + s + ` + "`\"`" + `), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *{{.Type}}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("parse {{.Type}}: %w", err)
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner. Databases store the number.
+func (id *{{.Type}}) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*id = {{.Type}}(v)
+		return nil
+	case []byte:
+		return id.scanString(string(v))
+	case string:
+		return id.scanString(v)
+	default:
+		return fmt.Errorf("scan {{.Type}}: unsupported type %T", src)
+	}
+}
+
+// scanString parses a number returned as text by the driver.
+func (id *{{.Type}}) scanString(s string) error {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("scan {{.Type}}: %w", err)
+	}
+	*id = {{.Type}}(n)
+	return nil
+}
+
+// Value implements driver.Valuer. Databases store the number.
+func (id {{.Type}}) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+{{end}}`))
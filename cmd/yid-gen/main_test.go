@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const exampleDir = "internal/example"
+
+// TestGenerate_UpToDate tests that the checked-in example matches the generator output.
+func TestGenerate_UpToDate(t *testing.T) {
+	pkg, err := parsePackage(exampleDir, DefaultOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := generate(pkg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(exampleDir, DefaultOutput))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s/%s is stale; run go generate ./%s", exampleDir, DefaultOutput, exampleDir)
+	}
+}
+
+// TestParsePackage tests that directives are parsed and types sorted.
+func TestParsePackage(t *testing.T) {
+	pkg, err := parsePackage(exampleDir, DefaultOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []idType{
+		{Type: "OrderID", Name: "order"},
+		{Type: "UserID", Name: "user", Prefix: "usr_", PadUp: 4},
+	}
+	if pkg.Name != "example" || len(pkg.Types) != len(expected) {
+		t.Fatalf("unexpected package: %+v", pkg)
+	}
+	for i := range expected {
+		if pkg.Types[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], pkg.Types[i])
+		}
+	}
+}
+
+// TestRun writes the generated file into a temporary package.
+func TestRun(t *testing.T) {
+	dir := writePackage(t, `package ids
+
+// ItemID identifies an item.
+//
+//yid:id name=item prefix=itm_
+type ItemID int64
+`)
+	if err := run([]string{"-output", "gen.go", dir}, io.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	src, err := os.ReadFile(filepath.Join(dir, "gen.go"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"package ids", "func ParseItemID(", `yid.WithPrefix("itm_")`} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("expected %q in generated code", want)
+		}
+	}
+
+	// Running again must skip the generated file instead of failing on it.
+	if err := run([]string{"-output", "gen.go", dir}, io.Discard); err != nil {
+		t.Fatalf("unexpected error on second run: %v", err)
+	}
+}
+
+// TestRun_Errors tests invalid declarations and directives.
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		reason string
+	}{
+		{"no types", "package ids\n\ntype ItemID int64\n", "no //yid:id types"},
+		{"not int64", "package ids\n\n//yid:id name=item\ntype ItemID string\n", "must be defined as int64"},
+		{"alias", "package ids\n\n//yid:id name=item\ntype ItemID = int64\n", "must be defined as int64"},
+		{"missing name", "package ids\n\n//yid:id prefix=itm_\ntype ItemID int64\n", "missing name"},
+		{"bad prefix", "package ids\n\n//yid:id name=item prefix=a/b\ntype ItemID int64\n", "prefix"},
+		{"bad pad", "package ids\n\n//yid:id name=item pad=99\ntype ItemID int64\n", "pad"},
+		{"malformed", "package ids\n\n//yid:id name\ntype ItemID int64\n", "malformed"},
+		{"unknown field", "package ids\n\n//yid:id name=item size=3\ntype ItemID int64\n", "unknown field"},
+		{"syntax error", "package ids\n\ntype ItemID int64 {\n", "expected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writePackage(t, tt.src)
+			err := run([]string{dir}, io.Discard)
+			if err == nil || !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("expected error containing %q, got %v", tt.reason, err)
+			}
+		})
+	}
+}
+
+// TestRun_MissingDir tests that a missing directory is reported.
+func TestRun_MissingDir(t *testing.T) {
+	if err := run([]string{filepath.Join(t.TempDir(), "missing")}, io.Discard); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+// TestRun_BadFlag tests that flag errors are returned.
+func TestRun_BadFlag(t *testing.T) {
+	if err := run([]string{"-nope"}, io.Discard); err == nil {
+		t.Error("expected error for unknown flag")
+	}
+}
+
+// writePackage writes src as ids.go in a new temporary directory.
+func writePackage(t *testing.T, src string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ids.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return dir
+}
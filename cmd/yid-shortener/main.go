@@ -0,0 +1,67 @@
+// Command yid-shortener runs the reference URL shortener.
+//
+// Usage:
+//
+//	yid-shortener -addr :8080 -key my-secret -pad 4 -data links.jsonl -base-url https://sho.rt
+//
+// Without -data, links are kept in memory and lost on exit.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/shortener"
+)
+
+func main() {
+	srv, err := newServer(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+	log.Printf("yid-shortener listening on %s", srv.Addr)
+	log.Fatal(srv.ListenAndServe())
+}
+
+// newServer builds the HTTP server from command-line arguments.
+func newServer(args []string, output io.Writer) (*http.Server, error) {
+	flags := flag.NewFlagSet("yid-shortener", flag.ContinueOnError)
+	flags.SetOutput(output)
+	addr := flags.String("addr", ":8080", "listen address")
+	key := flags.String("key", "", "secure key used to shuffle the dictionary")
+	padUp := flags.Int("pad", 0, "minimum code length (padUp)")
+	data := flags.String("data", "", "JSON Lines file to store links in (default: in memory)")
+	baseURL := flags.String("base-url", "", "prefix of returned short URLs (default: request host)")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	var store shortener.Store = shortener.NewMemoryStore()
+	if *data != "" {
+		fileStore, err := shortener.NewFileStore(*data)
+		if err != nil {
+			fmt.Fprintln(output, err)
+			return nil, err
+		}
+		store = fileStore
+	}
+
+	enc := yid.New(yid.WithSecureKey(*key), yid.WithPadUp(*padUp))
+	return &http.Server{
+		Addr:              *addr,
+		Handler:           shortener.New(store, enc, shortener.WithBaseURL(*baseURL)),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestNewServer tests building the server from flags.
+func TestNewServer(t *testing.T) {
+	data := filepath.Join(t.TempDir(), "links.jsonl")
+	srv, err := newServer([]string{"-addr", ":9999", "-key", "secret", "-pad", "3", "-data", data}, io.Discard)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if srv.Addr != ":9999" {
+		t.Errorf("expected addr :9999, got %s", srv.Addr)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/links", strings.NewReader(`{"url": "https://example.com"}`)))
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", rec.Code)
+	}
+}
+
+// TestNewServer_Errors tests invalid flags and store paths.
+func TestNewServer_Errors(t *testing.T) {
+	if _, err := newServer([]string{"-unknown"}, io.Discard); err == nil || errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected error for unknown flag, got %v", err)
+	}
+	if _, err := newServer([]string{"-h"}, io.Discard); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp for -h, got %v", err)
+	}
+	if _, err := newServer([]string{"-data", t.TempDir()}, io.Discard); err == nil {
+		t.Error("expected error for directory as data file")
+	}
+	if _, err := newServer(nil, io.Discard); err != nil {
+		t.Errorf("unexpected error for defaults: %v", err)
+	}
+}
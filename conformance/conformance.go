@@ -0,0 +1,173 @@
+// Package conformance runs the shared youtube-id test vectors against an
+// encoder implementation.
+//
+// The vectors live in testdata/vectors.json at the root of this repository
+// and are shared with the PHP, Python and TypeScript ports. Running them in
+// CI catches drift (for example in the tie-breaking of the secure dictionary
+// shuffle) before it changes IDs that have already been published.
+//
+// Example:
+//
+//	suite, err := conformance.LoadFile("testdata/vectors.json")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, f := range conformance.Run(suite, conformance.YID) {
+//		log.Println(f)
+//	}
+package conformance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// SuiteVersion is the vector file format version understood by this package.
+const SuiteVersion = 1
+
+// ErrUnsupportedVersion is returned when loading a vector file with an unknown version.
+var ErrUnsupportedVersion = errors.New("conformance: unsupported suite version")
+
+// ErrUnknownTransform is returned when a vector names an unknown transform.
+var ErrUnknownTransform = errors.New("conformance: unknown transform")
+
+// Vector is a single golden test case.
+// Number is stored as a decimal string in JSON so that ports without
+// 64-bit integers can read it losslessly.
+type Vector struct {
+	Number    int64  `json:"number,string"`
+	Key       string `json:"key"`
+	PadUp     int    `json:"padUp"`
+	Transform string `json:"transform"`
+	Expected  string `json:"expected"`
+}
+
+// Suite is a versioned set of vectors.
+type Suite struct {
+	Version     int      `json:"version"`
+	Description string   `json:"description,omitempty"`
+	Vectors     []Vector `json:"vectors"`
+}
+
+// Codec is the subset of the yid.Encoder API exercised by the vectors.
+type Codec interface {
+	Encode(number int64) (string, error)
+	EncodeRaw(number int64) (string, error)
+	Decode(alphanumeric string) (int64, error)
+}
+
+// Factory builds a Codec configured for the key, padUp and transform of a vector.
+type Factory func(v Vector) (Codec, error)
+
+// Failure describes a vector that an implementation did not reproduce.
+type Failure struct {
+	Index  int
+	Vector Vector
+	Reason string
+}
+
+// Error implements the error interface.
+func (f Failure) Error() string {
+	return fmt.Sprintf("conformance: vector %d (number=%d key=%q padUp=%d transform=%s): %s",
+		f.Index, f.Vector.Number, f.Vector.Key, f.Vector.PadUp, f.Vector.Transform, f.Reason)
+}
+
+// Load reads a suite from r and checks its version.
+func Load(r io.Reader) (*Suite, error) {
+	var s Suite
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("conformance: decode suite: %w", err)
+	}
+	if s.Version != SuiteVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, s.Version)
+	}
+	return &s, nil
+}
+
+// LoadFile reads a suite from the file at path.
+func LoadFile(path string) (*Suite, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: %w", err)
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// ParseTransform maps a vector transform name ("none", "upper", "lower") to a yid.Transform.
+// An empty name is treated as "none".
+func ParseTransform(name string) (yid.Transform, error) {
+	switch name {
+	case "", "none":
+		return yid.TransformNone, nil
+	case "upper":
+		return yid.TransformUpper, nil
+	case "lower":
+		return yid.TransformLower, nil
+	default:
+		return yid.TransformNone, fmt.Errorf("%w: %q", ErrUnknownTransform, name)
+	}
+}
+
+// YID is the Factory for this module's yid.Encoder.
+func YID(v Vector) (Codec, error) {
+	t, err := ParseTransform(v.Transform)
+	if err != nil {
+		return nil, err
+	}
+	return yid.New(yid.WithSecureKey(v.Key), yid.WithPadUp(v.PadUp), yid.WithTransform(t)), nil
+}
+
+// Run checks every vector in s against the codecs built by factory and
+// returns one Failure per vector that does not match.
+//
+// For each vector, Encode must return Expected, and Decode must reverse
+// EncodeRaw back to Number. For vectors without a transform, EncodeRaw must
+// also return Expected.
+func Run(s *Suite, factory Factory) []Failure {
+	var failures []Failure
+	for i, v := range s.Vectors {
+		if reason := check(v, factory); reason != "" {
+			failures = append(failures, Failure{Index: i, Vector: v, Reason: reason})
+		}
+	}
+	return failures
+}
+
+// check runs a single vector and returns a non-empty reason on mismatch.
+func check(v Vector, factory Factory) string {
+	codec, err := factory(v)
+	if err != nil {
+		return fmt.Sprintf("factory: %v", err)
+	}
+
+	encoded, err := codec.Encode(v.Number)
+	if err != nil {
+		return fmt.Sprintf("Encode: %v", err)
+	}
+	if encoded != v.Expected {
+		return fmt.Sprintf("Encode = %q, want %q", encoded, v.Expected)
+	}
+
+	raw, err := codec.EncodeRaw(v.Number)
+	if err != nil {
+		return fmt.Sprintf("EncodeRaw: %v", err)
+	}
+	if (v.Transform == "" || v.Transform == "none") && raw != v.Expected {
+		return fmt.Sprintf("EncodeRaw = %q, want %q", raw, v.Expected)
+	}
+
+	decoded, err := codec.Decode(raw)
+	if err != nil {
+		return fmt.Sprintf("Decode(%q): %v", raw, err)
+	}
+	if decoded != v.Number {
+		return fmt.Sprintf("Decode(%q) = %d, want %d", raw, decoded, v.Number)
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+package conformance_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/conformance"
+)
+
+const vectorsPath = "../testdata/vectors.json"
+
+// TestVectors runs the shared golden vectors against yid.Encoder.
+func TestVectors(t *testing.T) {
+	suite, err := conformance.LoadFile(vectorsPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(suite.Vectors) == 0 {
+		t.Fatal("expected vectors in suite")
+	}
+	for _, f := range conformance.Run(suite, conformance.YID) {
+		t.Error(f)
+	}
+}
+
+// TestLoad_UnsupportedVersion tests that unknown suite versions are rejected.
+func TestLoad_UnsupportedVersion(t *testing.T) {
+	_, err := conformance.Load(strings.NewReader(`{"version": 99, "vectors": []}`))
+	if !errors.Is(err, conformance.ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+// TestLoad_InvalidJSON tests that malformed input returns an error.
+func TestLoad_InvalidJSON(t *testing.T) {
+	if _, err := conformance.Load(strings.NewReader(`{`)); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+}
+
+// TestLoad_NumberAsString tests that numbers are read from decimal strings.
+func TestLoad_NumberAsString(t *testing.T) {
+	suite, err := conformance.Load(strings.NewReader(
+		`{"version": 1, "vectors": [{"number": "9223372036854775807", "key": "", "padUp": 0, "transform": "none", "expected": "kZviNa8fiMh"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if suite.Vectors[0].Number != 9223372036854775807 {
+		t.Errorf("expected max int64, got %d", suite.Vectors[0].Number)
+	}
+}
+
+// TestLoadFile_Missing tests that a missing file returns an error.
+func TestLoadFile_Missing(t *testing.T) {
+	if _, err := conformance.LoadFile("testdata/does-not-exist.json"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+// TestParseTransform tests transform name parsing.
+func TestParseTransform(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected yid.Transform
+	}{
+		{"", yid.TransformNone},
+		{"none", yid.TransformNone},
+		{"upper", yid.TransformUpper},
+		{"lower", yid.TransformLower},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conformance.ParseTransform(tt.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := conformance.ParseTransform("title"); !errors.Is(err, conformance.ErrUnknownTransform) {
+		t.Errorf("expected ErrUnknownTransform, got %v", err)
+	}
+}
+
+// driftCodec wraps an Encoder and corrupts one of its results.
+type driftCodec struct {
+	*yid.Encoder
+	encodeErr error
+	rawSuffix string
+	decodeOff int64
+	decodeErr error
+}
+
+func (c driftCodec) Encode(number int64) (string, error) {
+	if c.encodeErr != nil {
+		return "", c.encodeErr
+	}
+	return c.Encoder.Encode(number)
+}
+
+func (c driftCodec) EncodeRaw(number int64) (string, error) {
+	raw, err := c.Encoder.EncodeRaw(number)
+	return raw + c.rawSuffix, err
+}
+
+func (c driftCodec) Decode(alphanumeric string) (int64, error) {
+	if c.decodeErr != nil {
+		return 0, c.decodeErr
+	}
+	n, err := c.Encoder.Decode(alphanumeric)
+	return n + c.decodeOff, err
+}
+
+// TestRun_ReportsFailures tests that each kind of mismatch is reported.
+func TestRun_ReportsFailures(t *testing.T) {
+	boom := errors.New("boom")
+	suite := &conformance.Suite{
+		Version: conformance.SuiteVersion,
+		Vectors: []conformance.Vector{{Number: 12345, Transform: "none", Expected: "dnh"}},
+	}
+
+	tests := []struct {
+		name    string
+		factory conformance.Factory
+		reason  string
+	}{
+		{"factory error", func(conformance.Vector) (conformance.Codec, error) { return nil, boom }, "factory"},
+		{"encode error", codecFactory(driftCodec{encodeErr: boom}), "Encode: boom"},
+		{"raw mismatch", codecFactory(driftCodec{rawSuffix: "x"}), "EncodeRaw ="},
+		{"decode error", codecFactory(driftCodec{decodeErr: boom}), "Decode(\"dnh\"): boom"},
+		{"decode mismatch", codecFactory(driftCodec{decodeOff: 1}), "= 12346, want 12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := conformance.Run(suite, tt.factory)
+			if len(failures) != 1 {
+				t.Fatalf("expected 1 failure, got %d", len(failures))
+			}
+			if !strings.Contains(failures[0].Error(), tt.reason) {
+				t.Errorf("expected reason containing %q, got %q", tt.reason, failures[0].Error())
+			}
+		})
+	}
+}
+
+// TestRun_EncodeMismatch tests that a wrong expected value is reported.
+func TestRun_EncodeMismatch(t *testing.T) {
+	suite := &conformance.Suite{
+		Version: conformance.SuiteVersion,
+		Vectors: []conformance.Vector{{Number: 12345, Transform: "none", Expected: "xyz"}},
+	}
+	failures := conformance.Run(suite, conformance.YID)
+	if len(failures) != 1 || failures[0].Index != 0 {
+		t.Fatalf("expected 1 failure at index 0, got %v", failures)
+	}
+}
+
+// TestRun_UnknownTransform tests that the YID factory rejects unknown transforms.
+func TestRun_UnknownTransform(t *testing.T) {
+	suite := &conformance.Suite{
+		Version: conformance.SuiteVersion,
+		Vectors: []conformance.Vector{{Number: 1, Transform: "title", Expected: "b"}},
+	}
+	if failures := conformance.Run(suite, conformance.YID); len(failures) != 1 {
+		t.Errorf("expected 1 failure, got %d", len(failures))
+	}
+}
+
+// codecFactory returns a Factory that wraps the vector's yid.Encoder in c.
+func codecFactory(c driftCodec) conformance.Factory {
+	return func(v conformance.Vector) (conformance.Codec, error) {
+		codec, err := conformance.YID(v)
+		if err != nil {
+			return nil, err
+		}
+		c.Encoder = codec.(*yid.Encoder)
+		return c, nil
+	}
+}
@@ -0,0 +1,56 @@
+package yid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/wow-apps/youtube-id-go/internal/hkdf"
+)
+
+// keyInfo prefixes the HKDF info of derived secure keys.
+const keyInfo = "yid/v1 secure-key"
+
+// DeriveKey deterministically derives a secure key from a master secret and
+// a context (for example a tenant ID) using HKDF-SHA256. The result is meant
+// for WithSecureKey: each context gets its own shuffled dictionary, so IDs
+// are not portable between tenants, while only the master secret has to be
+// stored. Each context element is length-prefixed, so ("ab", "c") and
+// ("a", "bc") derive different keys. Returns ErrNoMasterSecret if master is
+// empty, since keys derived from it would be public.
+//
+// Example:
+//
+//	key, err := yid.DeriveKey(master, "tenant", "42")
+//	enc := yid.New(yid.WithSecureKey(key))
+func DeriveKey(master []byte, context ...string) (string, error) {
+	if len(master) == 0 {
+		return "", ErrNoMasterSecret
+	}
+	info := []byte(keyInfo)
+	for _, c := range context {
+		info = binary.BigEndian.AppendUint32(info, uint32(len(c)))
+		info = append(info, c...)
+	}
+	return hex.EncodeToString(hkdf.Key(master, nil, info, 32)), nil
+}
+
+// WithDerivedKey sets the obfuscation key to DeriveKey(master, context...).
+// It replaces any key set by WithSecureKey, and vice versa; the last option wins.
+// If master is empty, every method of the Encoder returns an error wrapping
+// ErrInvalidOption and ErrNoMasterSecret.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithDerivedKey(master, "tenant", "42"))
+func WithDerivedKey(master []byte, context ...string) Option {
+	key, err := DeriveKey(master, context...)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidOption, err)
+	}
+	return func(c *config) {
+		c.secureKey = key
+		c.keyed = key != ""
+		c.keyErr = err
+	}
+}
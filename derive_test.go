@@ -0,0 +1,138 @@
+package yid_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestDeriveKey_KnownValue pins the derivation so that other ports can reproduce it.
+func TestDeriveKey_KnownValue(t *testing.T) {
+	key, err := yid.DeriveKey([]byte("master-secret"), "tenant", "42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "033a2093be9cd8389f44ad00ceaf68cfa265214478eec12cb800bad11300cdb4"
+	if key != expected {
+		t.Errorf("expected %s, got %s", expected, key)
+	}
+}
+
+// deriveKey returns DeriveKey(master, context...), failing the test on error.
+func deriveKey(t *testing.T, master []byte, context ...string) string {
+	t.Helper()
+	key, err := yid.DeriveKey(master, context...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key
+}
+
+// TestDeriveKey_Contexts tests that contexts and master secrets produce distinct keys.
+func TestDeriveKey_Contexts(t *testing.T) {
+	master := []byte("master-secret")
+	keys := map[string]string{
+		"no context":    deriveKey(t, master),
+		"tenant 1":      deriveKey(t, master, "tenant-1"),
+		"tenant 2":      deriveKey(t, master, "tenant-2"),
+		"ab+c":          deriveKey(t, master, "ab", "c"),
+		"a+bc":          deriveKey(t, master, "a", "bc"),
+		"other master":  deriveKey(t, []byte("other"), "tenant-1"),
+		"empty context": deriveKey(t, master, ""),
+	}
+	seen := make(map[string]string)
+	for name, key := range keys {
+		if len(key) != 64 {
+			t.Errorf("%s: expected 64 hex characters, got %d", name, len(key))
+		}
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s and %s derived the same key", name, other)
+		}
+		seen[key] = name
+	}
+	if deriveKey(t, master, "tenant-1") != keys["tenant 1"] {
+		t.Error("expected derivation to be deterministic")
+	}
+}
+
+// TestDeriveKey_EmptyMaster tests that keys are never derived from an empty
+// master secret, which would make them public.
+func TestDeriveKey_EmptyMaster(t *testing.T) {
+	for _, master := range [][]byte{nil, {}} {
+		if _, err := yid.DeriveKey(master, "tenant-1"); !errors.Is(err, yid.ErrNoMasterSecret) {
+			t.Errorf("DeriveKey(%q): expected ErrNoMasterSecret, got %v", master, err)
+		}
+		enc := yid.New(yid.WithDerivedKey(master, "tenant-1"))
+		if _, err := enc.Encode(12345); !errors.Is(err, yid.ErrInvalidOption) || !errors.Is(err, yid.ErrNoMasterSecret) {
+			t.Errorf("WithDerivedKey(%q): expected ErrInvalidOption and ErrNoMasterSecret, got %v", master, err)
+		}
+		if _, err := yid.ToAlphanumeric(12345, yid.WithDerivedKey(master, "tenant-1")); !errors.Is(err, yid.ErrInvalidOption) {
+			t.Errorf("ToAlphanumeric: expected ErrInvalidOption, got %v", err)
+		}
+		if _, err := yid.NewRegistry(master).Derive("user"); !errors.Is(err, yid.ErrNoMasterSecret) {
+			t.Errorf("Derive: expected ErrNoMasterSecret, got %v", err)
+		}
+	}
+	if _, err := yid.ToAlphanumeric(12345); err != nil {
+		t.Errorf("expected the failed option not to affect other encoders, got %v", err)
+	}
+	enc := yid.New(yid.WithDerivedKey(nil, "tenant-1"), yid.WithSecureKey("explicit"))
+	if _, err := enc.Encode(12345); err != nil {
+		t.Errorf("expected a later WithSecureKey to replace the derived key, got %v", err)
+	}
+}
+
+// TestDeriveKey_EmptyMasterInvalidAlphabet tests that a failed key derivation
+// combined with an invalid alphabet is reported instead of panicking.
+func TestDeriveKey_EmptyMasterInvalidAlphabet(t *testing.T) {
+	enc := yid.New(yid.WithDerivedKey(nil, "x"), yid.WithAlphabet(strings.Repeat(yid.DefaultAlphabet, 2)[:65]))
+	if _, err := enc.Encode(12345); !errors.Is(err, yid.ErrNoMasterSecret) {
+		t.Errorf("expected ErrNoMasterSecret, got %v", err)
+	}
+	if _, err := enc.Decode("dnh"); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
+
+// TestWithDerivedKey tests that the option is equivalent to WithSecureKey(DeriveKey(...)).
+func TestWithDerivedKey(t *testing.T) {
+	master := []byte("master-secret")
+	derived, err := yid.ToAlphanumeric(12345, yid.WithDerivedKey(master, "tenant-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	explicit, err := yid.ToAlphanumeric(12345, yid.WithSecureKey(deriveKey(t, master, "tenant-1")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if derived != explicit {
+		t.Errorf("expected %s, got %s", explicit, derived)
+	}
+
+	otherTenant, err := yid.ToAlphanumeric(12345, yid.WithDerivedKey(master, "tenant-2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if otherTenant == derived {
+		t.Error("expected tenants to get different encodings")
+	}
+	if n, err := yid.ToNumeric(derived, yid.WithDerivedKey(master, "tenant-2")); err == nil && n == 12345 {
+		t.Error("expected IDs not to be portable between tenants")
+	}
+}
+
+// TestRegistry_DeriveMatchesWithDerivedKey tests that registries use the same derivation.
+func TestRegistry_DeriveMatchesWithDerivedKey(t *testing.T) {
+	master := []byte("master-secret")
+	enc, err := yid.NewRegistry(master).Derive("user")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fromRegistry, _ := enc.Encode(12345)
+	fromOption, _ := yid.ToAlphanumeric(12345, yid.WithDerivedKey(master, "user"))
+	if fromRegistry != fromOption {
+		t.Errorf("expected %s, got %s", fromOption, fromRegistry)
+	}
+}
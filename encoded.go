@@ -0,0 +1,17 @@
+package yid
+
+// Encoded is an ID in its encoded form, typically received from a client.
+// Packages such as sqlx decode Encoded values where a number is expected and
+// encode numbers into *Encoded destinations, so a string that has not been
+// decoded is never mistaken for a plain string parameter.
+//
+// Example:
+//
+//	id := yid.Encoded(r.PathValue("id"))
+//	db.QueryRowContext(ctx, "SELECT name FROM users WHERE id = ?", id)
+type Encoded string
+
+// String returns the encoded form.
+func (e Encoded) String() string {
+	return string(e)
+}
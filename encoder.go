@@ -1,6 +1,12 @@
 package yid
 
-import "github.com/wow-apps/youtube-id-go/internal/base62"
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/wow-apps/youtube-id-go/internal/base62"
+)
 
 // Encoder provides reusable encoding/decoding with preset options.
 // An Encoder is safe for concurrent use by multiple goroutines since all
@@ -16,6 +22,26 @@ type Encoder struct {
 	padUp      int
 	transform  Transform
 	dictionary string
+	keyed      bool
+	sortable   bool
+	randSource io.Reader
+	signKey    []byte
+	macs       *sync.Pool
+	signChars  int
+	name       string
+	observer   Observer
+	prefix     string
+	table      base62.Table
+	minLen     int
+	maxLen     int
+	groupSize  int
+	groupSep   string
+	letterCase letterCase
+	lookalikes bool
+	fold       [256]byte
+	expiryLen  int
+	expiryMax  int64
+	err        error
 }
 
 // New creates a new Encoder with the given options.
@@ -29,45 +55,174 @@ func New(opts ...Option) *Encoder {
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	return newEncoder(cfg)
+}
 
-	dictionary := base62.Dictionary
-	if cfg.secureKey != "" {
-		dictionary = base62.SecureDictionary(cfg.secureKey)
+// newEncoder builds an Encoder from an applied configuration and
+// precomputes its lookup tables.
+func newEncoder(cfg config) *Encoder {
+	// checkAlphabet also resets an invalid alphabet, so it runs even when
+	// an earlier option already failed.
+	err := cfg.checkAlphabet()
+	if cfg.keyErr != nil {
+		err = cfg.keyErr
 	}
 
-	return &Encoder{
+	e := &Encoder{
 		padUp:      cfg.padUp,
 		transform:  cfg.transform,
-		dictionary: dictionary,
+		dictionary: cfg.dictionary(),
+		keyed:      cfg.keyed,
+		sortable:   cfg.sortable,
+		randSource: cfg.randSource,
+		signChars:  cfg.signChars,
+		name:       cfg.name,
+		observer:   cfg.observer(),
+		prefix:     cfg.prefix,
+		groupSize:  cfg.groupSize,
+		groupSep:   cfg.groupSep,
+		lookalikes: cfg.lookalikes,
+		err:        err,
+	}
+	if e.err == nil {
+		e.err = cfg.checkSortable()
 	}
+	if e.err == nil {
+		e.err = validateGrouping(e.dictionary, e.groupSep)
+	}
+	e.signChars = min(e.signChars, maxSignatureChars(len(e.dictionary)))
+	e.signKey = bindSignKey(cfg.signKey, e.prefix, e.dictionary)
+	e.macs = newMACPool(e.signKey)
+	e.table = base62.NewTable(e.dictionary)
+	e.letterCase = caseOf(e.dictionary)
+	e.fold = newFold(e.dictionary, e.letterCase, e.lookalikes)
+	e.expiryLen, e.expiryMax = expiryDigits(len(e.dictionary))
+	e.minLen, e.maxLen = e.lengths()
+	return e
 }
 
 // Encode converts a number to an alphanumeric string with transformation applied.
-// Returns an error if number is negative.
+// Returns an error if number is negative or too large for the configured padUp.
 func (e *Encoder) Encode(number int64) (string, error) {
-	if number < 0 {
-		return "", ErrNegativeNumber
+	start := e.start()
+	result, err := e.encodeRaw(number)
+	if err == nil {
+		result = e.prefix + e.group(applyCaseTransform(result, e.transform))
 	}
-	result := base62.Encode(number, e.dictionary, e.padUp)
-	return applyCaseTransform(result, e.transform), nil
+	e.observeEncode(start, err)
+	return result, err
 }
 
 // EncodeRaw converts a number to an alphanumeric string without transformation.
 // Use this when you need the raw value for storage or decoding.
+// With WithSignature the signature characters are appended.
 // Returns an error if number is negative.
 func (e *Encoder) EncodeRaw(number int64) (string, error) {
-	if number < 0 {
-		return "", ErrNegativeNumber
+	start := e.start()
+	result, err := e.encodeRaw(number)
+	if err == nil {
+		result = e.prefix + result
+	}
+	e.observeEncode(start, err)
+	return result, err
+}
+
+// encodeRaw implements EncodeRaw without the prefix and without notifying the observer.
+func (e *Encoder) encodeRaw(number int64) (string, error) {
+	if e.err != nil {
+		return "", e.err
+	}
+	result, err := e.encodeNumber(number)
+	if err != nil {
+		return "", err
 	}
-	return base62.Encode(number, e.dictionary, e.padUp), nil
+	if e.signed() {
+		result += e.signature(signDomainID, number)
+	}
+	return result, nil
 }
 
 // Decode converts an alphanumeric string back to a number.
 // Expects the raw (non-transformed) value from EncodeRaw().
+// An empty string decodes to 0. Returns ErrOverflow if the value does not fit
+// in an int64, ErrInvalidLength in sortable mode if the input is not
+// SortableLength long, and ErrNotCanonical if the value is below the padUp
+// minimum.
+// With WithSignature, returns ErrInvalidSignature if the signature is missing
+// or does not match, and ErrNotCanonical for leading zero characters. With
+// WithPrefix, returns ErrInvalidPrefix if the input does not start with the
+// prefix. With WithGrouping or a case-insensitive alphabet, the input is
+// normalized first (see WithGrouping and WithAlphabet).
 func (e *Encoder) Decode(alphanumeric string) (int64, error) {
-	result, err := base62.Decode(alphanumeric, e.dictionary, e.padUp)
+	start := e.start()
+	result, err := e.decode(alphanumeric)
+	e.observeDecode(start, err)
+	return result, err
+}
+
+// decode implements Decode without notifying the observer.
+func (e *Encoder) decode(alphanumeric string) (int64, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	alphanumeric, err := e.trimPrefix(alphanumeric)
+	if err != nil {
+		return 0, err
+	}
+	alphanumeric = e.normalize(alphanumeric)
+	if !e.signed() {
+		return e.decodeNumber(alphanumeric)
+	}
+	payload, sig, err := e.split(alphanumeric)
+	if err != nil {
+		return 0, err
+	}
+	if err := e.canonical(payload); err != nil {
+		return 0, err
+	}
+	result, err := e.decodeNumber(payload)
+	if err != nil {
+		return 0, err
+	}
+	if err := e.verify(sig, signDomainID, result); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// encodeNumber encodes number with the dictionary, padUp and sortable
+// padding, without signature or transformation.
+func (e *Encoder) encodeNumber(number int64) (string, error) {
+	if number < 0 {
+		return "", ErrNegativeNumber
+	}
+	result, err := e.table.Encode(number, e.padUp)
+	if err != nil {
+		return "", translateError(err)
+	}
+	if e.sortable && len(result) < SortableLength {
+		result = strings.Repeat(e.dictionary[:1], SortableLength-len(result)) + result
+	}
+	return result, nil
+}
+
+// canonical returns ErrNotCanonical if s, an encoded number, has a leading
+// zero character that encodeNumber would not write.
+func (e *Encoder) canonical(s string) error {
+	if !e.sortable && len(s) > 1 && e.table.Digit(s[0]) == 0 {
+		return ErrNotCanonical
+	}
+	return nil
+}
+
+// decodeNumber reverses encodeNumber.
+func (e *Encoder) decodeNumber(s string) (int64, error) {
+	if e.sortable && len(s) != SortableLength {
+		return 0, ErrInvalidLength
+	}
+	result, err := e.table.Decode(s, e.padUp)
 	if err != nil {
-		return 0, ErrInvalidCharacter
+		return 0, translateError(err)
 	}
 	return result, nil
 }
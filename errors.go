@@ -1,9 +1,37 @@
 package yid
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/wow-apps/youtube-id-go/internal/base62"
+)
 
 // ErrInvalidCharacter is returned when decoding encounters an invalid character.
 var ErrInvalidCharacter = errors.New("yid: invalid character in input")
 
 // ErrNegativeNumber is returned when encoding a negative number.
 var ErrNegativeNumber = errors.New("yid: negative numbers are not supported")
+
+// ErrOverflow is returned when a value does not fit in an int64, either when
+// encoding a number too large for the configured padUp or when decoding a
+// string that represents a value larger than math.MaxInt64.
+var ErrOverflow = errors.New("yid: value overflows int64")
+
+// ErrInvalidLength is returned when the input or requested length is not valid
+// for the encoder configuration.
+var ErrInvalidLength = errors.New("yid: invalid length")
+
+// ErrInvalidSignature is returned when decoding a signed ID whose signature
+// is missing or does not match, for example a guessed or edited ID.
+var ErrInvalidSignature = errors.New("yid: invalid signature")
+
+// translateError maps errors from the base62 package to the package's own sentinel errors.
+func translateError(err error) error {
+	switch {
+	case errors.Is(err, base62.ErrOverflow):
+		return ErrOverflow
+	case errors.Is(err, base62.ErrBelowMinimum):
+		return ErrNotCanonical
+	}
+	return ErrInvalidCharacter
+}
@@ -0,0 +1,188 @@
+package yid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExpiryLength is the number of characters used for the expiry of an
+// expiring token with the default alphabet. Six base62 digits hold Unix
+// times until the year 3770. Other alphabets use as many digits as they
+// need to reach at least the year 3058 (2^35 seconds).
+const ExpiryLength = 6
+
+// MinExpiringSignatureBits is the signature strength EncodeExpiring and
+// DecodeExpiring require, counted as signature characters times log2 of the
+// alphabet size. A valid expiring token grants access until it expires, so
+// it needs about 2^35 guesses to forge rather than the few dozen a
+// one-character signature allows.
+const MinExpiringSignatureBits = 35
+
+// MinExpiringSignatureChars is the shortest signature that reaches
+// MinExpiringSignatureBits with the default alphabet. Smaller alphabets need
+// more characters, such as 7 for Crockford's base32 or 35 for binary.
+const MinExpiringSignatureChars = 6
+
+// minExpiryRange is the number of seconds every alphabet's expiry field covers.
+const minExpiryRange = 1 << 35
+
+// expiryDigits returns the number of digits of the expiry field in the
+// given base and the first Unix time that does not fit in it.
+func expiryDigits(base int) (int, int64) {
+	n, limit := 0, int64(1)
+	for limit < minExpiryRange {
+		limit *= int64(base)
+		n++
+	}
+	return n, limit
+}
+
+// ErrExpired is matched by errors.Is for the *ExpiredError returned when an
+// expiring token is decoded at or after its expiry.
+var ErrExpired = errors.New("yid: token expired")
+
+// ErrSignatureRequired is returned by EncodeExpiring and DecodeExpiring on
+// encoders without WithSignature: an unauthenticated expiry could be edited.
+var ErrSignatureRequired = errors.New("yid: signature key required")
+
+// ExpiredError reports an authentic token that is past its expiry.
+type ExpiredError struct {
+	ExpiresAt time.Time
+}
+
+// Error implements the error interface.
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("yid: token expired at %s", e.ExpiresAt.UTC().Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrExpired.
+func (e *ExpiredError) Is(target error) bool {
+	return target == ErrExpired
+}
+
+// EncodeExpiring packs number and expiresAt into a single signed token for
+// links that stop working, such as invites. The token is the expiry as
+// ExpiryLength dictionary characters, the encoded number, and a signature
+// over both, so neither can be edited. expiresAt is truncated to the second.
+// The encoder's transformation is applied, as with Encode.
+// Requires WithSignature; returns ErrSignatureRequired otherwise, and an
+// error wrapping ErrInvalidOption if the signature is weaker than
+// MinExpiringSignatureBits. Returns ErrNegativeNumber for expiry times
+// before 1970 and ErrOverflow for those that do not fit in ExpiryLength
+// characters.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithSignature(hmacKey, 6))
+//	token, _ := enc.EncodeExpiring(12345, time.Now().Add(72*time.Hour))
+func (e *Encoder) EncodeExpiring(number int64, expiresAt time.Time) (string, error) {
+	start := e.start()
+	token, err := e.encodeExpiring(number, expiresAt)
+	e.observeEncode(start, err)
+	return token, err
+}
+
+// encodeExpiring implements EncodeExpiring without notifying the observer.
+func (e *Encoder) encodeExpiring(number int64, expiresAt time.Time) (string, error) {
+	if e.err != nil {
+		return "", e.err
+	}
+	if err := e.checkExpiring(); err != nil {
+		return "", err
+	}
+	expiry := expiresAt.Unix()
+	if expiry < 0 {
+		return "", ErrNegativeNumber
+	}
+	if expiry >= e.expiryMax {
+		return "", ErrOverflow
+	}
+	body, err := e.encodeNumber(number)
+	if err != nil {
+		return "", err
+	}
+	prefix, err := e.table.Encode(expiry, 0)
+	if err != nil {
+		return "", translateError(err)
+	}
+	prefix = strings.Repeat(e.dictionary[:1], e.expiryLen-len(prefix)) + prefix
+	token := prefix + body + e.signature(signDomainExpiring, number, expiry)
+	return e.prefix + e.group(applyCaseTransform(token, e.transform)), nil
+}
+
+// checkExpiring reports whether the encoder's signature can protect
+// expiring tokens.
+func (e *Encoder) checkExpiring() error {
+	if !e.signed() {
+		return ErrSignatureRequired
+	}
+	if bits := signatureStrength(e.signChars, len(e.dictionary)); bits < MinExpiringSignatureBits {
+		return fmt.Errorf("%w: expiring tokens need signatures of at least %d bits, got %.1f (%d characters of a %d-character alphabet)",
+			ErrInvalidOption, MinExpiringSignatureBits, bits, e.signChars, len(e.dictionary))
+	}
+	return nil
+}
+
+// DecodeExpiring verifies a raw token from EncodeExpiring and returns its
+// number. now is the current time; pass time.Now() in production and a fixed
+// time in tests. The signature is checked before the expiry, so an
+// *ExpiredError (matching ErrExpired) always carries an authentic ExpiresAt.
+// Returns ErrInvalidSignature for tokens that were not issued by this
+// encoder's key, including plain signed IDs.
+//
+// Example:
+//
+//	n, err := enc.DecodeExpiring(token, time.Now())
+//	var expired *yid.ExpiredError
+//	if errors.As(err, &expired) {
+//		log.Printf("invite expired at %s", expired.ExpiresAt)
+//	}
+func (e *Encoder) DecodeExpiring(token string, now time.Time) (int64, error) {
+	start := e.start()
+	number, err := e.decodeExpiring(token, now)
+	e.observeDecode(start, err)
+	return number, err
+}
+
+// decodeExpiring implements DecodeExpiring without notifying the observer.
+func (e *Encoder) decodeExpiring(token string, now time.Time) (int64, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	if err := e.checkExpiring(); err != nil {
+		return 0, err
+	}
+	token, err := e.trimPrefix(token)
+	if err != nil {
+		return 0, err
+	}
+	token = e.normalize(token)
+	payload, sig, err := e.split(token)
+	if err != nil {
+		return 0, err
+	}
+	if len(payload) <= e.expiryLen {
+		return 0, ErrInvalidSignature
+	}
+	expiry, err := e.table.Decode(payload[:e.expiryLen], 0)
+	if err != nil {
+		return 0, translateError(err)
+	}
+	if err := e.canonical(payload[e.expiryLen:]); err != nil {
+		return 0, err
+	}
+	number, err := e.decodeNumber(payload[e.expiryLen:])
+	if err != nil {
+		return 0, err
+	}
+	if err := e.verify(sig, signDomainExpiring, number, expiry); err != nil {
+		return 0, err
+	}
+	expiresAt := time.Unix(expiry, 0)
+	if !now.Before(expiresAt) {
+		return 0, &ExpiredError{ExpiresAt: expiresAt}
+	}
+	return number, nil
+}
@@ -0,0 +1,211 @@
+package yid_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+var (
+	issuedAt  = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt = issuedAt.Add(72 * time.Hour)
+)
+
+// TestExpiring_Roundtrip tests that a token decodes before its expiry.
+func TestExpiring_Roundtrip(t *testing.T) {
+	encoders := map[string]*yid.Encoder{
+		"default":  yid.New(yid.WithSignature(signKey, 6)),
+		"secure":   yid.New(yid.WithSignature(signKey, 6), yid.WithSecureKey("secret")),
+		"sortable": yid.New(yid.WithSignature(signKey, 6), yid.WithSortable()),
+	}
+	for name, enc := range encoders {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int64{0, 1, 12345, 1 << 50} {
+				token, err := enc.EncodeExpiring(n, expiresAt)
+				if err != nil {
+					t.Fatalf("EncodeExpiring(%d) error: %v", n, err)
+				}
+				decoded, err := enc.DecodeExpiring(token, issuedAt)
+				if err != nil {
+					t.Fatalf("DecodeExpiring(%q) error: %v", token, err)
+				}
+				if decoded != n {
+					t.Errorf("roundtrip failed: %d -> %q -> %d", n, token, decoded)
+				}
+			}
+		})
+	}
+}
+
+// TestExpiring_Expired tests that tokens are rejected at and after their expiry.
+func TestExpiring_Expired(t *testing.T) {
+	enc := yid.New(yid.WithSignature(signKey, 6))
+	token, _ := enc.EncodeExpiring(12345, expiresAt)
+
+	if _, err := enc.DecodeExpiring(token, expiresAt.Add(-time.Second)); err != nil {
+		t.Fatalf("expected token to be valid one second before expiry, got %v", err)
+	}
+	for _, now := range []time.Time{expiresAt, expiresAt.Add(time.Hour)} {
+		_, err := enc.DecodeExpiring(token, now)
+		if !errors.Is(err, yid.ErrExpired) {
+			t.Fatalf("expected ErrExpired at %s, got %v", now, err)
+		}
+		var expired *yid.ExpiredError
+		if !errors.As(err, &expired) {
+			t.Fatalf("expected *ExpiredError, got %T", err)
+		}
+		if !expired.ExpiresAt.Equal(expiresAt) {
+			t.Errorf("expected expiry %s, got %s", expiresAt, expired.ExpiresAt)
+		}
+	}
+}
+
+// TestExpiring_Tampered tests that edited expiries and plain IDs are rejected.
+func TestExpiring_Tampered(t *testing.T) {
+	enc := yid.New(yid.WithSignature(signKey, 6))
+	token, _ := enc.EncodeExpiring(12345, expiresAt)
+	later, _ := enc.EncodeExpiring(12345, expiresAt.Add(365*24*time.Hour))
+	plain, _ := enc.EncodeRaw(12345)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"extended expiry", later[:yid.ExpiryLength] + token[yid.ExpiryLength:]},
+		{"edited number", token[:yid.ExpiryLength] + "dni" + token[yid.ExpiryLength+3:]},
+		{"plain signed ID", plain},
+		{"empty", ""},
+		{"expiry only", token[:yid.ExpiryLength] + token[len(token)-6:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := enc.DecodeExpiring(tt.input, issuedAt); !errors.Is(err, yid.ErrInvalidSignature) {
+				t.Errorf("DecodeExpiring(%q): expected ErrInvalidSignature, got %v", tt.input, err)
+			}
+		})
+	}
+
+	if _, err := enc.Decode(token); !errors.Is(err, yid.ErrInvalidSignature) {
+		t.Errorf("expected Decode to reject expiring token, got %v", err)
+	}
+}
+
+// TestExpiring_LeadingZeros tests that a zero character before the number
+// is rejected rather than accepted as another form of the same token.
+func TestExpiring_LeadingZeros(t *testing.T) {
+	enc := yid.New(yid.WithSignature(signKey, 6))
+	token, _ := enc.EncodeExpiring(12345, expiresAt)
+	padded := token[:yid.ExpiryLength] + "a" + token[yid.ExpiryLength:]
+	if _, err := enc.DecodeExpiring(padded, issuedAt); !errors.Is(err, yid.ErrNotCanonical) {
+		t.Errorf("expected ErrNotCanonical, got %v", err)
+	}
+}
+
+// TestExpiring_SignatureRequired tests that unsigned encoders refuse expiring tokens.
+func TestExpiring_SignatureRequired(t *testing.T) {
+	enc := yid.New()
+	if _, err := enc.EncodeExpiring(12345, expiresAt); !errors.Is(err, yid.ErrSignatureRequired) {
+		t.Errorf("expected ErrSignatureRequired, got %v", err)
+	}
+	if _, err := enc.DecodeExpiring("aaaaaadnh", issuedAt); !errors.Is(err, yid.ErrSignatureRequired) {
+		t.Errorf("expected ErrSignatureRequired, got %v", err)
+	}
+}
+
+// TestExpiring_ShortSignature tests that signatures too short to resist
+// guessing are refused.
+func TestExpiring_ShortSignature(t *testing.T) {
+	short := yid.New(yid.WithSignature(signKey, yid.MinExpiringSignatureChars-1))
+	if _, err := short.EncodeExpiring(12345, expiresAt); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("EncodeExpiring: expected ErrInvalidOption, got %v", err)
+	}
+	if _, err := short.DecodeExpiring("aaaaaadnhaaaaa", issuedAt); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("DecodeExpiring: expected ErrInvalidOption, got %v", err)
+	}
+	if _, err := short.Encode(12345); err != nil {
+		t.Errorf("expected plain IDs to keep working, got %v", err)
+	}
+
+	enc := yid.New(yid.WithSignature(signKey, yid.MinExpiringSignatureChars))
+	token, err := enc.EncodeExpiring(12345, expiresAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, err := enc.DecodeExpiring(token, issuedAt); err != nil || n != 12345 {
+		t.Errorf("expected 12345, got %d, %v", n, err)
+	}
+}
+
+// TestExpiring_SignatureBits tests that the minimum signature length scales
+// with the alphabet size.
+func TestExpiring_SignatureBits(t *testing.T) {
+	tests := []struct {
+		alphabet string
+		weak     int
+		strong   int
+	}{
+		{yid.DefaultAlphabet, 5, 6},
+		{yid.CrockfordAlphabet, 6, 7},
+		{"0123456789", 10, 11},
+		{"01", 34, 35},
+	}
+	for _, tt := range tests {
+		weak := yid.New(yid.WithAlphabet(tt.alphabet), yid.WithSignature(signKey, tt.weak))
+		if _, err := weak.EncodeExpiring(12345, expiresAt); !errors.Is(err, yid.ErrInvalidOption) {
+			t.Errorf("%d-character alphabet, %d characters: expected ErrInvalidOption, got %v", len(tt.alphabet), tt.weak, err)
+		}
+		strong := yid.New(yid.WithAlphabet(tt.alphabet), yid.WithSignature(signKey, tt.strong))
+		token, err := strong.EncodeExpiring(12345, expiresAt)
+		if err != nil {
+			t.Fatalf("%d-character alphabet, %d characters: unexpected error: %v", len(tt.alphabet), tt.strong, err)
+		}
+		if n, err := strong.DecodeExpiring(token, issuedAt); err != nil || n != 12345 {
+			t.Errorf("%d-character alphabet: expected 12345, got %d, %v", len(tt.alphabet), n, err)
+		}
+	}
+}
+
+// TestExpiring_ExpiryRange tests the supported range of expiry times.
+func TestExpiring_ExpiryRange(t *testing.T) {
+	enc := yid.New(yid.WithSignature(signKey, 6))
+	tests := []struct {
+		name     string
+		expires  time.Time
+		expected error
+	}{
+		{"epoch", time.Unix(0, 0), nil},
+		{"before epoch", time.Unix(-1, 0), yid.ErrNegativeNumber},
+		{"year 3000", time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"year 4000", time.Date(4000, 1, 1, 0, 0, 0, 0, time.UTC), yid.ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := enc.EncodeExpiring(1, tt.expires)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			if err == nil && len(token) != yid.ExpiryLength+1+6 {
+				t.Errorf("expected fixed-width expiry, got %q", token)
+			}
+		})
+	}
+}
+
+// TestExpiring_NegativeNumber tests that negative numbers are rejected.
+func TestExpiring_NegativeNumber(t *testing.T) {
+	enc := yid.New(yid.WithSignature(signKey, 6))
+	if _, err := enc.EncodeExpiring(-1, expiresAt); !errors.Is(err, yid.ErrNegativeNumber) {
+		t.Errorf("expected ErrNegativeNumber, got %v", err)
+	}
+}
+
+// TestExpiredError_Message tests the error message.
+func TestExpiredError_Message(t *testing.T) {
+	err := &yid.ExpiredError{ExpiresAt: expiresAt}
+	expected := "yid: token expired at 2026-03-04T12:00:00Z"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
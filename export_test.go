@@ -0,0 +1,9 @@
+package yid
+
+// CachedEncoders returns the number of encoders cached for the package-level functions.
+func CachedEncoders() int {
+	return defaultCache.len()
+}
+
+// EncoderCacheSize is the bound of the package-level encoder cache.
+const EncoderCacheSize = encoderCacheSize
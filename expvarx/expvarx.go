@@ -0,0 +1,68 @@
+// Package expvarx exports yid encode/decode counters through the standard
+// expvar package, so they appear on /debug/vars.
+//
+// Counters are kept in an expvar.Map keyed by
+// "<encoder>.<op>.<class>", where encoder is the name from yid.WithName
+// ("default" if unnamed), op is "encode" or "decode" and class is
+// yid.Classify of the result. Total latency is kept under
+// "<encoder>.<op>.nanoseconds".
+//
+// Example:
+//
+//	obs := expvarx.Publish("yid")
+//	enc := yid.New(yid.WithName("user"), yid.WithObserver(obs))
+//	// /debug/vars -> {"yid": {"user.decode.ok": 10, "user.decode.invalid_character": 2, ...}}
+package expvarx
+
+import (
+	"expvar"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// DefaultEncoderName is used in keys for encoders without a name.
+const DefaultEncoderName = "default"
+
+// Observer is a yid.Observer that counts events in an expvar.Map.
+// It is safe for concurrent use.
+type Observer struct {
+	vars *expvar.Map
+}
+
+// New returns an Observer that counts into m.
+func New(m *expvar.Map) *Observer {
+	return &Observer{vars: m}
+}
+
+// Publish creates an expvar.Map published under name and returns an
+// Observer counting into it. Like expvar.NewMap, it panics if name is
+// already published.
+func Publish(name string) *Observer {
+	return New(expvar.NewMap(name))
+}
+
+// Map returns the map the observer counts into.
+func (o *Observer) Map() *expvar.Map {
+	return o.vars
+}
+
+// OnEncode implements yid.Observer.
+func (o *Observer) OnEncode(ev yid.Event) {
+	o.record("encode", ev)
+}
+
+// OnDecode implements yid.Observer.
+func (o *Observer) OnDecode(ev yid.Event) {
+	o.record("decode", ev)
+}
+
+// record counts ev under the given operation.
+func (o *Observer) record(op string, ev yid.Event) {
+	name := ev.Encoder
+	if name == "" {
+		name = DefaultEncoderName
+	}
+	prefix := name + "." + op + "."
+	o.vars.Add(prefix+ev.Class(), 1)
+	o.vars.Add(prefix+"nanoseconds", int64(ev.Duration))
+}
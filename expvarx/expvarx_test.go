@@ -0,0 +1,56 @@
+package expvarx_test
+
+import (
+	"expvar"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/expvarx"
+)
+
+// value returns the integer stored under key in m, or 0.
+func value(m *expvar.Map, key string) int64 {
+	if v, ok := m.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+// TestObserver_Counts tests per-encoder, per-class counters.
+func TestObserver_Counts(t *testing.T) {
+	obs := expvarx.New(new(expvar.Map).Init())
+	user := yid.New(yid.WithName("user"), yid.WithObserver(obs))
+	anon := yid.New(yid.WithSignature([]byte("key"), 4), yid.WithObserver(obs))
+
+	user.Encode(12345)
+	user.Decode("dnh")
+	user.Decode("dn-")
+	user.Decode("dn-")
+	user.Decode("ZZZZZZZZZZZZ")
+	anon.Decode("dnhAAAA")
+
+	tests := map[string]int64{
+		"user.encode.ok":                   1,
+		"user.decode.ok":                   1,
+		"user.decode.invalid_character":    2,
+		"user.decode.overflow":             1,
+		"default.decode.invalid_signature": 1,
+		"default.encode.ok":                0,
+	}
+	for key, expected := range tests {
+		if got := value(obs.Map(), key); got != expected {
+			t.Errorf("%s: expected %d, got %d", key, expected, got)
+		}
+	}
+	if obs.Map().Get("user.decode.nanoseconds") == nil {
+		t.Error("expected decode latency to be recorded")
+	}
+}
+
+// TestPublish tests that the map is published under the given name.
+func TestPublish(t *testing.T) {
+	obs := expvarx.Publish("yid_test")
+	if expvar.Get("yid_test") != obs.Map() {
+		t.Error("expected map to be published")
+	}
+}
@@ -0,0 +1,82 @@
+package yid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithGrouping splits the output of Encode and EncodeExpiring into groups of
+// size characters joined by sep, such as "ABCD-EFGH", for codes that people
+// read aloud or type. Decode and DecodeExpiring remove sep and whitespace
+// before decoding, so grouped, partially grouped and ungrouped input all
+// decode. EncodeRaw is never grouped. The prefix is not part of any group.
+//
+// size <= 0 disables grouping. sep must not contain alphabet characters;
+// otherwise every method of the Encoder returns an error wrapping
+// ErrInvalidOption.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithAlphabet(yid.CrockfordAlphabet), yid.WithPadUp(8), yid.WithGrouping(4, "-"))
+//	code, _ := enc.Encode(12345) // -> "1000-0C1S"
+//	enc.Decode(" 1ooo oc1s ")     // -> 12345
+func WithGrouping(size int, sep string) Option {
+	return func(c *config) {
+		if size <= 0 {
+			size, sep = 0, ""
+		}
+		c.groupSize = size
+		c.groupSep = sep
+	}
+}
+
+// validateGrouping checks that sep cannot be mistaken for an ID character.
+func validateGrouping(dictionary, sep string) error {
+	if strings.ContainsAny(sep, dictionary) {
+		return fmt.Errorf("%w: group separator %q contains alphabet characters", ErrInvalidOption, sep)
+	}
+	return nil
+}
+
+// group inserts the separator between groups of s.
+func (e *Encoder) group(s string) string {
+	if e.groupSize == 0 || len(s) <= e.groupSize {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s) + (len(s)-1)/e.groupSize*len(e.groupSep))
+	for i := 0; i < len(s); i += e.groupSize {
+		if i > 0 {
+			b.WriteString(e.groupSep)
+		}
+		b.WriteString(s[i:min(i+e.groupSize, len(s))])
+	}
+	return b.String()
+}
+
+// normalizes reports whether Decode normalizes its input.
+func (e *Encoder) normalizes() bool {
+	return e.groupSize > 0 || e.letterCase != mixedCase || e.lookalikes
+}
+
+// normalize removes separators and whitespace from s and, for
+// case-insensitive alphabets and WithLookalikes, folds case and look-alike
+// characters.
+func (e *Encoder) normalize(s string) string {
+	if !e.normalizes() {
+		return s
+	}
+	if e.groupSep != "" {
+		s = strings.ReplaceAll(s, e.groupSep, "")
+	}
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		b = append(b, e.fold[c])
+	}
+	return string(b)
+}
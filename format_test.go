@@ -0,0 +1,121 @@
+package yid_test
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"testing"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestGrouping_Encode tests the documented grouped output and its decoding.
+func TestGrouping_Encode(t *testing.T) {
+	enc := yid.New(yid.WithAlphabet(yid.CrockfordAlphabet), yid.WithPadUp(8), yid.WithGrouping(4, "-"))
+	code, err := enc.Encode(12345)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != "1000-0C1S" {
+		t.Errorf("expected 1000-0C1S, got %q", code)
+	}
+	raw, _ := enc.EncodeRaw(12345)
+	if raw != "10000C1S" {
+		t.Errorf("expected raw 10000C1S, got %q", raw)
+	}
+	for _, input := range []string{"1000-0C1S", "10000C1S", " 1ooo oc1s ", "1000-\t0c1s\n", "10-000-c1s"} {
+		if n, err := enc.Decode(input); err != nil || n != 12345 {
+			t.Errorf("Decode(%q) = %d, %v, want 12345", input, n, err)
+		}
+		if !enc.Valid(input) {
+			t.Errorf("Valid(%q) = false, want true", input)
+		}
+	}
+}
+
+// TestGrouping_Sizes tests group boundaries for several lengths.
+func TestGrouping_Sizes(t *testing.T) {
+	tests := []struct {
+		opts []yid.Option
+		n    int64
+		want string
+	}{
+		{[]yid.Option{yid.WithGrouping(3, " ")}, 0, "a"},
+		{[]yid.Option{yid.WithGrouping(3, " ")}, 12345, "dnh"},
+		{[]yid.Option{yid.WithGrouping(3, " ")}, 1 << 40, "twk sJO 6"},
+		{[]yid.Option{yid.WithGrouping(2, "--"), yid.WithPrefix("usr_")}, 12345, "usr_dn--h"},
+		{[]yid.Option{yid.WithGrouping(0, "-")}, 12345, "dnh"},
+	}
+	for _, tt := range tests {
+		got, err := yid.New(tt.opts...).Encode(tt.n)
+		if err != nil || got != tt.want {
+			t.Errorf("Encode(%d) = %q, %v, want %q", tt.n, got, err, tt.want)
+		}
+	}
+}
+
+// TestGrouping_SignedAndExpiring tests that groups span signatures and expiring tokens.
+func TestGrouping_SignedAndExpiring(t *testing.T) {
+	enc := yid.New(yid.WithSignature([]byte("hmac-secret"), 6), yid.WithGrouping(4, "-"))
+	code, _ := enc.Encode(12345)
+	if len(code) != 11 || code[4] != '-' || code[9] != '-' {
+		t.Errorf("expected 4-4-1 grouping, got %q", code)
+	}
+	if n, err := enc.Decode(code); err != nil || n != 12345 {
+		t.Errorf("expected 12345, got %d, %v", n, err)
+	}
+
+	token, err := enc.EncodeExpiring(7, time.Unix(2000, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, err := enc.DecodeExpiring(token, time.Unix(1000, 0)); err != nil || n != 7 {
+		t.Errorf("expected 7, got %d, %v", n, err)
+	}
+}
+
+// TestGrouping_InvalidSeparator tests that separators must not be alphabet characters.
+func TestGrouping_InvalidSeparator(t *testing.T) {
+	enc := yid.New(yid.WithGrouping(4, "x"))
+	if _, err := enc.Encode(1); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+	if _, err := yid.New(yid.WithAlphabet(yid.CrockfordAlphabet), yid.WithGrouping(4, "x")).Encode(1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestGrouping_Pattern tests that the pattern and schema describe grouped output.
+func TestGrouping_Pattern(t *testing.T) {
+	encoders := map[string][]yid.Option{
+		"plain":  {yid.WithGrouping(3, ".")},
+		"padUp":  {yid.WithGrouping(4, "-"), yid.WithPadUp(5), yid.WithPrefix("inv_")},
+		"signed": {yid.WithGrouping(4, " - "), yid.WithSignature([]byte("hmac-secret"), 4)},
+	}
+	rng := rand.New(rand.NewSource(6))
+	for name, opts := range encoders {
+		t.Run(name, func(t *testing.T) {
+			enc := yid.New(opts...)
+			schema := enc.JSONSchema()
+			re := regexp.MustCompile(schema.Pattern)
+			for i := 0; i < 2000; i++ {
+				n := rng.Int63() >> uint(rng.Intn(63))
+				s, err := enc.Encode(n)
+				if err != nil {
+					continue
+				}
+				if !re.MatchString(s) {
+					t.Fatalf("Encode(%d) = %q does not match %s", n, s, schema.Pattern)
+				}
+				if len(s) < schema.MinLength || len(s) > schema.MaxLength {
+					t.Fatalf("Encode(%d) = %q outside length [%d, %d]", n, s, schema.MinLength, schema.MaxLength)
+				}
+				raw, _ := enc.EncodeRaw(n)
+				if len(raw) > 4+len("inv_") && re.MatchString(raw) {
+					t.Fatalf("ungrouped %q matches %s", raw, schema.Pattern)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package yid_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+const dictionary = "abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// FuzzToNumeric checks that decoding arbitrary input never panics, only
+// fails with the package's sentinel errors, and that re-encoding a decoded
+// value decodes back to the same value.
+func FuzzToNumeric(f *testing.F) {
+	f.Add("dnh", "", 0)
+	f.Add("", "", 3)
+	f.Add("kZviNa8fiMh", "", 0)
+	f.Add("kZviNa8fiMi", "secret", 0)
+	f.Add("abc!", "", 0)
+
+	f.Fuzz(func(t *testing.T, s, key string, padUp int) {
+		opts := []yid.Option{yid.WithSecureKey(key), yid.WithPadUp(padUp)}
+		n, err := yid.ToNumeric(s, opts...)
+		if err != nil {
+			switch {
+			case errors.Is(err, yid.ErrInvalidCharacter), errors.Is(err, yid.ErrOverflow),
+				errors.Is(err, yid.ErrInvalidLength), errors.Is(err, yid.ErrNotCanonical):
+			default:
+				t.Fatalf("ToNumeric(%q) returned unexpected error: %v", s, err)
+			}
+			return
+		}
+		if n < 0 {
+			t.Fatalf("ToNumeric(%q) = %d without error", s, n)
+		}
+
+		canonical, err := yid.ToAlphanumeric(n, opts...)
+		if err != nil {
+			t.Fatalf("ToAlphanumeric(%d) error: %v", n, err)
+		}
+		again, err := yid.ToNumeric(canonical, opts...)
+		if err != nil {
+			t.Fatalf("ToNumeric(%q) error: %v", canonical, err)
+		}
+		if again != n {
+			t.Errorf("roundtrip failed: %q -> %d -> %q -> %d", s, n, canonical, again)
+		}
+	})
+}
+
+// FuzzEncoderDecode checks that Decode reverses EncodeRaw for every
+// encodable number and that output only contains dictionary characters.
+func FuzzEncoderDecode(f *testing.F) {
+	f.Add(int64(0), "", 0)
+	f.Add(int64(12345), "secret", 3)
+	f.Add(int64(math.MaxInt64), "", 0)
+	f.Add(int64(math.MaxInt64), "", yid.MaxPadUp)
+	f.Add(int64(-1), "", 0)
+
+	f.Fuzz(func(t *testing.T, n int64, key string, padUp int) {
+		enc := yid.New(yid.WithSecureKey(key), yid.WithPadUp(padUp))
+		encoded, err := enc.EncodeRaw(n)
+		switch {
+		case n < 0:
+			if !errors.Is(err, yid.ErrNegativeNumber) {
+				t.Fatalf("EncodeRaw(%d): expected ErrNegativeNumber, got %v", n, err)
+			}
+			return
+		case errors.Is(err, yid.ErrOverflow):
+			return
+		case err != nil:
+			t.Fatalf("EncodeRaw(%d) error: %v", n, err)
+		}
+
+		for i := 0; i < len(encoded); i++ {
+			if strings.IndexByte(dictionary, encoded[i]) == -1 {
+				t.Fatalf("EncodeRaw(%d) = %q contains non-dictionary character %q", n, encoded, encoded[i])
+			}
+		}
+
+		decoded, err := enc.Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode(%q) error: %v", encoded, err)
+		}
+		if decoded != n {
+			t.Errorf("roundtrip failed: %d -> %q -> %d", n, encoded, decoded)
+		}
+	})
+}
+
+// FuzzEncoderInjective checks that no two numbers encode to the same string
+// under the same key and padUp.
+func FuzzEncoderInjective(f *testing.F) {
+	f.Add(int64(0), int64(1), "", 0)
+	f.Add(int64(61), int64(62), "secret", 3)
+	f.Add(int64(math.MaxInt64-1), int64(math.MaxInt64), "", 0)
+
+	f.Fuzz(func(t *testing.T, a, b int64, key string, padUp int) {
+		if a == b {
+			return
+		}
+		enc := yid.New(yid.WithSecureKey(key), yid.WithPadUp(padUp))
+		encodedA, errA := enc.EncodeRaw(a)
+		encodedB, errB := enc.EncodeRaw(b)
+		if errA != nil || errB != nil {
+			return
+		}
+		if encodedA == encodedB {
+			t.Errorf("%d and %d both encode to %q", a, b, encodedA)
+		}
+	})
+}
@@ -0,0 +1,245 @@
+// Package gen mints new Snowflake-style IDs and emits them as yid strings.
+//
+// Each ID is a positive int64 composed of a millisecond timestamp relative to
+// a custom epoch, a node ID and a per-millisecond sequence number:
+//
+//	| 1 bit unused | 41 bits timestamp | 10 bits node | 12 bits sequence |
+//
+// The number is then encoded with a yid.Encoder, so IDs minted later decode
+// to larger numbers.
+//
+// Example:
+//
+//	g, err := gen.New(yid.New(yid.WithSecureKey("my-secret")), 7)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	id, _ := g.Next()      // -> short string
+//	parts, _ := g.Parse(id) // -> time, node 7, sequence
+package gen
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+const (
+	// TimestampBits is the number of bits holding milliseconds since the epoch (~69 years).
+	TimestampBits = 41
+	// NodeBits is the number of bits holding the node ID.
+	NodeBits = 10
+	// SequenceBits is the number of bits holding the per-millisecond sequence.
+	SequenceBits = 12
+
+	// MaxNode is the largest valid node ID.
+	MaxNode = 1<<NodeBits - 1
+	// MaxSequence is the largest sequence number within one millisecond.
+	MaxSequence = 1<<SequenceBits - 1
+
+	maxTimestamp = 1<<TimestampBits - 1
+	nodeShift    = SequenceBits
+	timeShift    = SequenceBits + NodeBits
+)
+
+// DefaultEpoch is the epoch used when WithEpoch is not given.
+var DefaultEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// DefaultMaxRollback is how far the clock may move backwards before
+// Next returns ErrClockRollback instead of waiting for it to catch up.
+const DefaultMaxRollback = 10 * time.Millisecond
+
+// ErrInvalidNode is returned when the node ID is outside [0, MaxNode].
+var ErrInvalidNode = errors.New("gen: node ID out of range")
+
+// ErrClockRollback is returned when the clock moved backwards by more than the allowed rollback.
+var ErrClockRollback = errors.New("gen: clock moved backwards")
+
+// ErrBeforeEpoch is returned when the clock reads earlier than the epoch.
+var ErrBeforeEpoch = errors.New("gen: clock is before epoch")
+
+// ErrTimestampOverflow is returned when the timestamp no longer fits in TimestampBits.
+var ErrTimestampOverflow = errors.New("gen: timestamp exceeds available bits")
+
+// ErrInvalidID is returned when parsing a number that was not minted by a generator.
+var ErrInvalidID = errors.New("gen: invalid ID")
+
+// Clock provides the current time and a way to wait. It is injectable for tests.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// config holds generator configuration.
+type config struct {
+	epoch       time.Time
+	clock       Clock
+	maxRollback time.Duration
+}
+
+// Option configures a Generator.
+type Option func(*config)
+
+// WithEpoch sets the custom epoch timestamps are measured from.
+func WithEpoch(epoch time.Time) Option {
+	return func(c *config) {
+		c.epoch = epoch
+	}
+}
+
+// WithClock sets the clock used to read time and wait.
+func WithClock(clock Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// WithMaxRollback sets how far the clock may move backwards before Next
+// fails with ErrClockRollback. Smaller rollbacks are absorbed by waiting.
+// Negative values are treated as 0.
+func WithMaxRollback(d time.Duration) Option {
+	return func(c *config) {
+		if d < 0 {
+			d = 0
+		}
+		c.maxRollback = d
+	}
+}
+
+// ID holds the components of a minted ID.
+type ID struct {
+	Time     time.Time
+	Node     int64
+	Sequence int64
+}
+
+// Generator mints unique IDs for one node.
+// A Generator is safe for concurrent use by multiple goroutines.
+type Generator struct {
+	enc         *yid.Encoder
+	node        int64
+	epoch       time.Time
+	clock       Clock
+	maxRollback int64
+
+	mu       sync.Mutex
+	last     int64
+	sequence int64
+}
+
+// New creates a Generator for the given node ID that encodes IDs with enc.
+// Returns ErrInvalidNode if node is outside [0, MaxNode].
+func New(enc *yid.Encoder, node int64, opts ...Option) (*Generator, error) {
+	if node < 0 || node > MaxNode {
+		return nil, ErrInvalidNode
+	}
+
+	cfg := config{
+		epoch:       DefaultEpoch,
+		clock:       systemClock{},
+		maxRollback: DefaultMaxRollback,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Generator{
+		enc:         enc,
+		node:        node,
+		epoch:       cfg.epoch,
+		clock:       cfg.clock,
+		maxRollback: cfg.maxRollback.Milliseconds(),
+		last:        -1,
+	}, nil
+}
+
+// Next mints a new ID and returns its encoded form.
+func (g *Generator) Next() (string, error) {
+	n, err := g.NextInt64()
+	if err != nil {
+		return "", err
+	}
+	return g.enc.Encode(n)
+}
+
+// NextInt64 mints a new ID and returns it as a number.
+//
+// If the clock moved backwards by at most the allowed rollback, NextInt64
+// waits for it to catch up. If the sequence for the current millisecond is
+// exhausted, it waits for the next millisecond.
+func (g *Generator) NextInt64() (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.millis()
+	if now < 0 {
+		return 0, ErrBeforeEpoch
+	}
+	if now < g.last {
+		if g.last-now > g.maxRollback {
+			return 0, ErrClockRollback
+		}
+		now = g.waitUntil(g.last)
+	}
+
+	if now == g.last {
+		g.sequence = (g.sequence + 1) & MaxSequence
+		if g.sequence == 0 {
+			now = g.waitUntil(g.last + 1)
+		}
+	} else {
+		g.sequence = 0
+	}
+
+	if now > maxTimestamp {
+		return 0, ErrTimestampOverflow
+	}
+
+	g.last = now
+	return now<<timeShift | g.node<<nodeShift | g.sequence, nil
+}
+
+// Parse decodes an encoded ID back into its components.
+func (g *Generator) Parse(id string) (ID, error) {
+	n, err := g.enc.Decode(id)
+	if err != nil {
+		return ID{}, err
+	}
+	return g.Decompose(n)
+}
+
+// Decompose splits a numeric ID into its components.
+// Returns ErrInvalidID for negative numbers.
+func (g *Generator) Decompose(n int64) (ID, error) {
+	if n < 0 {
+		return ID{}, ErrInvalidID
+	}
+	return ID{
+		Time:     g.epoch.Add(time.Duration(n>>timeShift) * time.Millisecond),
+		Node:     (n >> nodeShift) & MaxNode,
+		Sequence: n & MaxSequence,
+	}, nil
+}
+
+// millis returns the milliseconds elapsed since the epoch.
+func (g *Generator) millis() int64 {
+	return g.clock.Now().Sub(g.epoch).Milliseconds()
+}
+
+// waitUntil sleeps until the clock reaches target milliseconds and returns the current reading.
+func (g *Generator) waitUntil(target int64) int64 {
+	now := g.millis()
+	for now < target {
+		g.clock.Sleep(time.Duration(target-now) * time.Millisecond)
+		now = g.millis()
+	}
+	return now
+}
@@ -0,0 +1,247 @@
+package gen_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/gen"
+)
+
+// fakeClock is a manually driven Clock. Sleep advances the time.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps int
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps++
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// newFake returns a generator on node 5 driven by a fake clock one hour after the epoch.
+func newFake(t *testing.T, opts ...gen.Option) (*gen.Generator, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{now: gen.DefaultEpoch.Add(time.Hour)}
+	g, err := gen.New(yid.New(), 5, append([]gen.Option{gen.WithClock(clock)}, opts...)...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return g, clock
+}
+
+// TestNew_InvalidNode tests that out-of-range node IDs are rejected.
+func TestNew_InvalidNode(t *testing.T) {
+	for _, node := range []int64{-1, gen.MaxNode + 1} {
+		if _, err := gen.New(yid.New(), node); !errors.Is(err, gen.ErrInvalidNode) {
+			t.Errorf("node %d: expected ErrInvalidNode, got %v", node, err)
+		}
+	}
+}
+
+// TestNext_ParseRoundtrip tests that Parse recovers the components of a minted ID.
+func TestNext_ParseRoundtrip(t *testing.T) {
+	g, clock := newFake(t)
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parts, err := g.Parse(id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !parts.Time.Equal(clock.Now()) {
+		t.Errorf("expected time %v, got %v", clock.Now(), parts.Time)
+	}
+	if parts.Node != 5 {
+		t.Errorf("expected node 5, got %d", parts.Node)
+	}
+	if parts.Sequence != 0 {
+		t.Errorf("expected sequence 0, got %d", parts.Sequence)
+	}
+}
+
+// TestNext_SequenceWithinMillisecond tests that IDs in the same millisecond increment the sequence.
+func TestNext_SequenceWithinMillisecond(t *testing.T) {
+	g, _ := newFake(t)
+	first, err := g.NextInt64()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := g.NextInt64()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second != first+1 {
+		t.Errorf("expected %d, got %d", first+1, second)
+	}
+}
+
+// TestNext_SequenceOverflow tests that exhausting the sequence waits for the next millisecond.
+func TestNext_SequenceOverflow(t *testing.T) {
+	g, clock := newFake(t)
+	start := clock.Now()
+	var last int64
+	for i := 0; i <= gen.MaxSequence+1; i++ {
+		n, err := g.NextInt64()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n <= last {
+			t.Fatalf("expected increasing IDs, got %d after %d", n, last)
+		}
+		last = n
+	}
+	if clock.sleeps != 1 {
+		t.Errorf("expected 1 sleep, got %d", clock.sleeps)
+	}
+	parts, err := g.Decompose(last)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !parts.Time.Equal(start.Add(time.Millisecond)) || parts.Sequence != 0 {
+		t.Errorf("expected sequence 0 in next millisecond, got %+v", parts)
+	}
+}
+
+// TestNext_SmallRollback tests that a rollback within the tolerance waits for the clock.
+func TestNext_SmallRollback(t *testing.T) {
+	g, clock := newFake(t)
+	first, err := g.NextInt64()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Set(clock.Now().Add(-5 * time.Millisecond))
+	second, err := g.NextInt64()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second <= first {
+		t.Errorf("expected %d > %d", second, first)
+	}
+	if clock.sleeps == 0 {
+		t.Error("expected generator to wait for the clock")
+	}
+}
+
+// TestNext_LargeRollback tests that a rollback beyond the tolerance is reported.
+func TestNext_LargeRollback(t *testing.T) {
+	g, clock := newFake(t, gen.WithMaxRollback(time.Millisecond))
+	if _, err := g.NextInt64(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Set(clock.Now().Add(-time.Second))
+	if _, err := g.Next(); !errors.Is(err, gen.ErrClockRollback) {
+		t.Errorf("expected ErrClockRollback, got %v", err)
+	}
+}
+
+// TestNext_NegativeMaxRollback tests that a negative tolerance rejects any rollback.
+func TestNext_NegativeMaxRollback(t *testing.T) {
+	g, clock := newFake(t, gen.WithMaxRollback(-time.Second))
+	if _, err := g.NextInt64(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock.Set(clock.Now().Add(-time.Millisecond))
+	if _, err := g.NextInt64(); !errors.Is(err, gen.ErrClockRollback) {
+		t.Errorf("expected ErrClockRollback, got %v", err)
+	}
+}
+
+// TestNext_BeforeEpoch tests that a clock earlier than the epoch is reported.
+func TestNext_BeforeEpoch(t *testing.T) {
+	g, _ := newFake(t, gen.WithEpoch(time.Now().Add(24*time.Hour)))
+	if _, err := g.NextInt64(); !errors.Is(err, gen.ErrBeforeEpoch) {
+		t.Errorf("expected ErrBeforeEpoch, got %v", err)
+	}
+}
+
+// TestNext_TimestampOverflow tests that timestamps beyond 41 bits are reported.
+func TestNext_TimestampOverflow(t *testing.T) {
+	g, clock := newFake(t)
+	clock.Set(gen.DefaultEpoch.Add(time.Duration(1<<gen.TimestampBits) * time.Millisecond))
+	if _, err := g.NextInt64(); !errors.Is(err, gen.ErrTimestampOverflow) {
+		t.Errorf("expected ErrTimestampOverflow, got %v", err)
+	}
+}
+
+// TestNext_CustomEpoch tests that timestamps are relative to the custom epoch.
+func TestNext_CustomEpoch(t *testing.T) {
+	epoch := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: epoch.Add(3 * time.Millisecond)}
+	g, err := gen.New(yid.New(), 0, gen.WithEpoch(epoch), gen.WithClock(clock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n, err := g.NextInt64()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := int64(3) << (gen.NodeBits + gen.SequenceBits); n != want {
+		t.Errorf("expected %d, got %d", want, n)
+	}
+}
+
+// TestNext_Concurrent tests that concurrent callers never receive duplicate IDs.
+func TestNext_Concurrent(t *testing.T) {
+	g, err := gen.New(yid.New(yid.WithSecureKey("secret")), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const workers, perWorker = 8, 2000
+	ids := make(chan string, workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id, err := g.Next()
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				ids <- id
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate ID %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+// TestParse_Invalid tests that invalid strings and numbers are rejected.
+func TestParse_Invalid(t *testing.T) {
+	g, _ := newFake(t)
+	if _, err := g.Parse("abc!"); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter, got %v", err)
+	}
+	if _, err := g.Decompose(-1); !errors.Is(err, gen.ErrInvalidID) {
+		t.Errorf("expected ErrInvalidID, got %v", err)
+	}
+}
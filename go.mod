@@ -1,3 +1,3 @@
 module github.com/wow-apps/youtube-id-go
 
-go 1.21
+go 1.22
@@ -0,0 +1,277 @@
+// Package guard rate-limits invalid decode attempts to slow down bots that
+// sweep the ID keyspace.
+//
+// A Guard wraps a yid.Encoder (or any Decoder) and keeps a token bucket per
+// caller key, such as a client IP or API token. Every failed decode takes a
+// token; tokens refill at a fixed rate up to a burst size. Valid IDs cost
+// nothing, so well-behaved callers are never limited. Buckets live in a
+// pluggable Store, which only holds callers that recently failed;
+// MemoryStore keeps them in process.
+//
+// Example:
+//
+//	g := guard.New(yid.New(yid.WithSecureKey("my-secret")))
+//	id, decision, err := g.Decode(clientIP, r.PathValue("id"))
+//	switch {
+//	case decision == guard.Deny:
+//		http.Error(w, "too many requests", http.StatusTooManyRequests)
+//	case err != nil:
+//		http.NotFound(w, r)
+//	}
+package guard
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Default limits used when WithLimit and WithSlowBelow are not given:
+// a burst of 20 invalid attempts, refilled at one per 10 seconds, with
+// Slow decisions once 5 or fewer attempts remain.
+const (
+	DefaultRate      = 0.1
+	DefaultBurst     = 20
+	DefaultSlowBelow = 5
+)
+
+// ErrDenied is returned by Decode when the caller has no invalid attempts left.
+// The input is not decoded.
+var ErrDenied = errors.New("guard: too many invalid attempts")
+
+// Decoder is the subset of the yid.Encoder API used by the guard.
+type Decoder interface {
+	Decode(alphanumeric string) (int64, error)
+}
+
+// Decision tells the caller how to treat a request.
+type Decision int
+
+const (
+	// Allow means the caller is within its limit.
+	Allow Decision = iota
+	// Slow means the caller is close to its limit; callers may delay the
+	// response or require a challenge.
+	Slow
+	// Deny means the caller exhausted its limit; the input was not decoded.
+	Deny
+)
+
+// String returns the lower-case name of the decision.
+func (d Decision) String() string {
+	switch d {
+	case Allow:
+		return "allow"
+	case Slow:
+		return "slow"
+	case Deny:
+		return "deny"
+	default:
+		return fmt.Sprintf("Decision(%d)", int(d))
+	}
+}
+
+// Event describes one guarded decode for metrics hooks.
+// Err is the decode error, ErrDenied, or a store error; Remaining is the
+// number of invalid attempts the caller has left.
+type Event struct {
+	Key       string
+	Decision  Decision
+	Err       error
+	Remaining float64
+}
+
+// Clock provides the current time. It is injectable for tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// config holds guard configuration.
+type config struct {
+	rate      float64
+	burst     float64
+	slowBelow float64
+	store     Store
+	clock     Clock
+	observers []func(Event)
+}
+
+// Option configures a Guard.
+type Option func(*config)
+
+// WithLimit sets the refill rate in invalid attempts per second and the
+// burst size. Non-positive values keep the defaults.
+func WithLimit(rate float64, burst int) Option {
+	return func(c *config) {
+		if rate > 0 {
+			c.rate = rate
+		}
+		if burst > 0 {
+			c.burst = float64(burst)
+		}
+	}
+}
+
+// WithSlowBelow sets the number of remaining attempts at or below which the
+// decision is Slow instead of Allow. Zero disables Slow decisions.
+func WithSlowBelow(n int) Option {
+	return func(c *config) {
+		c.slowBelow = float64(n)
+	}
+}
+
+// WithStore sets the bucket store. Defaults to a new MemoryStore.
+func WithStore(s Store) Option {
+	return func(c *config) {
+		c.store = s
+	}
+}
+
+// WithClock sets the clock used to refill buckets. Defaults to the system clock.
+func WithClock(clock Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// WithObserver adds a hook called after every Decode, for example to count
+// decisions or export metrics. Hooks run synchronously and must be fast.
+func WithObserver(fn func(Event)) Option {
+	return func(c *config) {
+		c.observers = append(c.observers, fn)
+	}
+}
+
+// Guard rate-limits invalid decodes per caller key.
+// It is safe for concurrent use if its Store is.
+type Guard struct {
+	dec Decoder
+	cfg config
+}
+
+// New returns a Guard that decodes with dec.
+func New(dec Decoder, opts ...Option) *Guard {
+	cfg := config{
+		rate:      DefaultRate,
+		burst:     DefaultBurst,
+		slowBelow: DefaultSlowBelow,
+		store:     nil,
+		clock:     systemClock{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.store == nil {
+		cfg.store = NewMemoryStore()
+	}
+	return &Guard{dec: dec, cfg: cfg}
+}
+
+// Decode decodes s on behalf of the caller identified by key.
+//
+// If the caller has no attempts left, Decode returns Deny and ErrDenied
+// without decoding. Otherwise one attempt is reserved before decoding, in
+// the same store update that checks the limit, so concurrent invalid
+// attempts from one caller are all counted. A failure keeps the reservation
+// and the returned decision reflects what is left afterwards; a success
+// refunds it, so valid IDs cost nothing, and a caller whose bucket has
+// refilled is removed from the store. Store errors are returned wrapped,
+// with a Deny decision.
+func (g *Guard) Decode(key, s string) (int64, Decision, error) {
+	var reserved bool
+	b, err := g.cfg.store.Update(key, func(b Bucket, found bool) (Bucket, bool) {
+		b, keep := g.refill(b, found)
+		if reserved = b.Tokens >= 1; reserved {
+			b.Tokens--
+			keep = true
+		}
+		return b, keep
+	})
+	if err != nil {
+		return g.finish(key, 0, Deny, fmt.Errorf("guard: store: %w", err), 0)
+	}
+	if !reserved {
+		return g.finish(key, 0, Deny, ErrDenied, b.Tokens)
+	}
+
+	n, decodeErr := g.dec.Decode(s)
+	if decodeErr != nil {
+		return g.finish(key, 0, g.decide(b.Tokens), decodeErr, b.Tokens)
+	}
+	b, err = g.cfg.store.Update(key, g.refund)
+	if err != nil {
+		return g.finish(key, 0, Deny, fmt.Errorf("guard: store: %w", err), 0)
+	}
+	return g.finish(key, n, g.decide(b.Tokens), nil, b.Tokens)
+}
+
+// Check returns the current decision for key without decoding anything.
+func (g *Guard) Check(key string) (Decision, error) {
+	b, err := g.cfg.store.Update(key, g.refill)
+	if err != nil {
+		return Deny, fmt.Errorf("guard: store: %w", err)
+	}
+	return g.decide(b.Tokens), nil
+}
+
+// Reset refills the bucket of key, for example after the caller solved a challenge.
+func (g *Guard) Reset(key string) error {
+	now := g.cfg.clock.Now()
+	_, err := g.cfg.store.Update(key, func(Bucket, bool) (Bucket, bool) {
+		return Bucket{Tokens: g.cfg.burst, Updated: now}, false
+	})
+	if err != nil {
+		return fmt.Errorf("guard: store: %w", err)
+	}
+	return nil
+}
+
+// refill returns b with the tokens accrued since it was last updated, and
+// whether the bucket needs storing: full buckets are not kept.
+func (g *Guard) refill(b Bucket, found bool) (Bucket, bool) {
+	now := g.cfg.clock.Now()
+	if !found {
+		return Bucket{Tokens: g.cfg.burst, Updated: now}, false
+	}
+	if elapsed := now.Sub(b.Updated).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(g.cfg.burst, b.Tokens+elapsed*g.cfg.rate)
+		b.Updated = now
+	}
+	return b, b.Tokens < g.cfg.burst
+}
+
+// refund refills b and gives back the token reserved by Decode.
+func (g *Guard) refund(b Bucket, found bool) (Bucket, bool) {
+	b, _ = g.refill(b, found)
+	b.Tokens = math.Min(g.cfg.burst, b.Tokens+1)
+	return b, b.Tokens < g.cfg.burst
+}
+
+// decide maps the remaining tokens to a decision.
+func (g *Guard) decide(tokens float64) Decision {
+	switch {
+	case tokens < 1:
+		return Deny
+	case tokens <= g.cfg.slowBelow:
+		return Slow
+	default:
+		return Allow
+	}
+}
+
+// finish notifies observers and returns its arguments.
+func (g *Guard) finish(key string, n int64, d Decision, err error, remaining float64) (int64, Decision, error) {
+	if len(g.cfg.observers) > 0 {
+		ev := Event{Key: key, Decision: d, Err: err, Remaining: remaining}
+		for _, fn := range g.cfg.observers {
+			fn(ev)
+		}
+	}
+	return n, d, err
+}
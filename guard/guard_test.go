@@ -0,0 +1,333 @@
+package guard_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/guard"
+)
+
+// fakeClock is a manually driven Clock.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newGuard(opts ...guard.Option) (*guard.Guard, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	enc := yid.New(yid.WithSignature([]byte("hmac-secret"), 6))
+	return guard.New(enc, append([]guard.Option{guard.WithClock(clock)}, opts...)...), clock
+}
+
+// TestGuard_ValidIDsAreFree tests that valid decodes never consume attempts.
+func TestGuard_ValidIDsAreFree(t *testing.T) {
+	g, _ := newGuard(guard.WithLimit(1, 2), guard.WithSlowBelow(0))
+	valid, _ := yid.New(yid.WithSignature([]byte("hmac-secret"), 6)).EncodeRaw(12345)
+	for i := 0; i < 10; i++ {
+		n, d, err := g.Decode("1.2.3.4", valid)
+		if err != nil || d != guard.Allow || n != 12345 {
+			t.Fatalf("attempt %d: got %d, %v, %v", i, n, d, err)
+		}
+	}
+}
+
+// TestGuard_InvalidAttempts tests the progression from Allow to Slow to Deny.
+func TestGuard_InvalidAttempts(t *testing.T) {
+	g, _ := newGuard(guard.WithLimit(0.1, 4), guard.WithSlowBelow(2))
+	expected := []guard.Decision{guard.Allow, guard.Slow, guard.Slow, guard.Deny}
+	for i, want := range expected {
+		_, d, err := g.Decode("1.2.3.4", "guessed")
+		if !errors.Is(err, yid.ErrInvalidSignature) {
+			t.Fatalf("attempt %d: expected decode error, got %v", i, err)
+		}
+		if d != want {
+			t.Errorf("attempt %d: expected %v, got %v", i, want, d)
+		}
+	}
+
+	_, d, err := g.Decode("1.2.3.4", "guessed")
+	if d != guard.Deny || !errors.Is(err, guard.ErrDenied) {
+		t.Errorf("expected Deny and ErrDenied, got %v, %v", d, err)
+	}
+}
+
+// TestGuard_DenySkipsDecode tests that denied callers cannot decode even valid IDs.
+func TestGuard_DenySkipsDecode(t *testing.T) {
+	g, _ := newGuard(guard.WithLimit(0.1, 1))
+	g.Decode("1.2.3.4", "guessed")
+
+	valid, _ := yid.New(yid.WithSignature([]byte("hmac-secret"), 6)).EncodeRaw(12345)
+	n, d, err := g.Decode("1.2.3.4", valid)
+	if n != 0 || d != guard.Deny || !errors.Is(err, guard.ErrDenied) {
+		t.Errorf("expected denial, got %d, %v, %v", n, d, err)
+	}
+}
+
+// TestGuard_Refill tests that attempts refill over time up to the burst.
+func TestGuard_Refill(t *testing.T) {
+	g, clock := newGuard(guard.WithLimit(0.5, 2), guard.WithSlowBelow(0))
+	g.Decode("1.2.3.4", "guessed")
+	g.Decode("1.2.3.4", "guessed")
+	if d, _ := g.Check("1.2.3.4"); d != guard.Deny {
+		t.Fatalf("expected Deny, got %v", d)
+	}
+
+	clock.Advance(2 * time.Second)
+	if d, _ := g.Check("1.2.3.4"); d != guard.Allow {
+		t.Fatalf("expected Allow after refill, got %v", d)
+	}
+
+	clock.Advance(time.Hour)
+	g.Decode("1.2.3.4", "guessed")
+	g.Decode("1.2.3.4", "guessed")
+	if d, _ := g.Check("1.2.3.4"); d != guard.Deny {
+		t.Errorf("expected refill to be capped at burst, got %v", d)
+	}
+}
+
+// TestGuard_KeysAreIndependent tests that one caller does not affect another.
+func TestGuard_KeysAreIndependent(t *testing.T) {
+	g, _ := newGuard(guard.WithLimit(0.1, 1), guard.WithSlowBelow(0))
+	g.Decode("bot", "guessed")
+	if d, _ := g.Check("bot"); d != guard.Deny {
+		t.Errorf("expected bot to be denied, got %v", d)
+	}
+	if d, _ := g.Check("user"); d != guard.Allow {
+		t.Errorf("expected user to be allowed, got %v", d)
+	}
+}
+
+// TestGuard_Reset tests that Reset refills a bucket.
+func TestGuard_Reset(t *testing.T) {
+	g, _ := newGuard(guard.WithLimit(0.1, 1))
+	g.Decode("1.2.3.4", "guessed")
+	if err := g.Reset("1.2.3.4"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d, _ := g.Check("1.2.3.4"); d == guard.Deny {
+		t.Error("expected caller to be allowed after Reset")
+	}
+}
+
+// TestGuard_Observer tests that hooks receive every decision.
+func TestGuard_Observer(t *testing.T) {
+	var events []guard.Event
+	g, _ := newGuard(guard.WithLimit(0.1, 1), guard.WithObserver(func(ev guard.Event) {
+		events = append(events, ev)
+	}))
+	g.Decode("1.2.3.4", "guessed")
+	g.Decode("1.2.3.4", "guessed")
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Key != "1.2.3.4" || !errors.Is(events[0].Err, yid.ErrInvalidSignature) || events[0].Remaining != 0 {
+		t.Errorf("unexpected first event: %+v", events[0])
+	}
+	if events[1].Decision != guard.Deny || !errors.Is(events[1].Err, guard.ErrDenied) {
+		t.Errorf("unexpected second event: %+v", events[1])
+	}
+}
+
+// failingStore is a Store that always fails.
+type failingStore struct{ err error }
+
+func (s failingStore) Update(string, func(guard.Bucket, bool) (guard.Bucket, bool)) (guard.Bucket, error) {
+	return guard.Bucket{}, s.err
+}
+
+// TestGuard_StoreError tests that store errors are returned with a Deny decision.
+func TestGuard_StoreError(t *testing.T) {
+	boom := errors.New("boom")
+	g, _ := newGuard(guard.WithStore(failingStore{boom}))
+
+	if _, d, err := g.Decode("1.2.3.4", "dnh"); d != guard.Deny || !errors.Is(err, boom) {
+		t.Errorf("Decode: expected Deny and store error, got %v, %v", d, err)
+	}
+	if d, err := g.Check("1.2.3.4"); d != guard.Deny || !errors.Is(err, boom) {
+		t.Errorf("Check: expected Deny and store error, got %v, %v", d, err)
+	}
+	if err := g.Reset("1.2.3.4"); !errors.Is(err, boom) {
+		t.Errorf("Reset: expected store error, got %v", err)
+	}
+}
+
+// TestGuard_Concurrent tests that concurrent invalid attempts are all counted.
+func TestGuard_Concurrent(t *testing.T) {
+	g, _ := newGuard(guard.WithLimit(0.001, 50))
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Decode("1.2.3.4", "guessed")
+		}()
+	}
+	wg.Wait()
+	if d, _ := g.Check("1.2.3.4"); d != guard.Deny {
+		t.Errorf("expected Deny after 100 attempts, got %v", d)
+	}
+}
+
+// blockingDecoder is a Decoder that fails every decode after release is closed.
+type blockingDecoder struct {
+	calls   atomic.Int64
+	release chan struct{}
+}
+
+func (d *blockingDecoder) Decode(string) (int64, error) {
+	d.calls.Add(1)
+	<-d.release
+	return 0, yid.ErrInvalidCharacter
+}
+
+// TestGuard_ConcurrentDecodesReserve tests that attempts in flight count
+// against the limit, so parallel invalid decodes cannot exceed the burst.
+func TestGuard_ConcurrentDecodesReserve(t *testing.T) {
+	const attempts, burst = 200, 5
+	dec := &blockingDecoder{release: make(chan struct{})}
+	g := guard.New(dec, guard.WithLimit(0.0001, burst))
+
+	var denied atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := g.Decode("bot", "guessed"); errors.Is(err, guard.ErrDenied) {
+				denied.Add(1)
+			}
+		}()
+	}
+	for dec.calls.Load()+denied.Load() < attempts {
+		time.Sleep(time.Millisecond)
+	}
+	close(dec.release)
+	wg.Wait()
+
+	if calls := dec.calls.Load(); calls != burst {
+		t.Errorf("expected %d decodes to reach the decoder, got %d", burst, calls)
+	}
+}
+
+// TestGuard_ValidRefundsReservation tests that a valid decode gives back its attempt.
+func TestGuard_ValidRefundsReservation(t *testing.T) {
+	g, _ := newGuard(guard.WithLimit(0.1, 2), guard.WithSlowBelow(0))
+	g.Decode("1.2.3.4", "guessed")
+	valid, _ := yid.New(yid.WithSignature([]byte("hmac-secret"), 6)).EncodeRaw(12345)
+	for i := 0; i < 3; i++ {
+		if _, d, err := g.Decode("1.2.3.4", valid); err != nil || d != guard.Allow {
+			t.Fatalf("attempt %d: expected Allow, got %v, %v", i, d, err)
+		}
+	}
+	if _, d, _ := g.Decode("1.2.3.4", "guessed"); d != guard.Deny {
+		t.Errorf("expected the last attempt to be left, got %v", d)
+	}
+}
+
+// TestDecision_String tests decision names.
+func TestDecision_String(t *testing.T) {
+	tests := map[guard.Decision]string{
+		guard.Allow:       "allow",
+		guard.Slow:        "slow",
+		guard.Deny:        "deny",
+		guard.Decision(9): "Decision(9)",
+	}
+	for d, expected := range tests {
+		if d.String() != expected {
+			t.Errorf("expected %q, got %q", expected, d.String())
+		}
+	}
+}
+
+// TestMemoryStore_Prune tests that idle buckets are removed.
+func TestMemoryStore_Prune(t *testing.T) {
+	store := guard.NewMemoryStore()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g := guard.New(yid.New(), guard.WithStore(store), guard.WithClock(clock), guard.WithLimit(0.0001, 5))
+
+	g.Decode("old", "a-b")
+	clock.Advance(time.Hour)
+	g.Decode("new", "a-b")
+
+	if removed := store.Prune(clock.Now().Add(-time.Minute)); removed != 1 {
+		t.Errorf("expected 1 bucket pruned, got %d", removed)
+	}
+	if store.Len() != 1 {
+		t.Errorf("expected 1 bucket left, got %d", store.Len())
+	}
+}
+
+// TestMemoryStore_FullBucketsNotStored tests that callers with a full bucket
+// take no space in the store.
+func TestMemoryStore_FullBucketsNotStored(t *testing.T) {
+	store := guard.NewMemoryStore()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g := guard.New(yid.New(), guard.WithStore(store), guard.WithClock(clock), guard.WithLimit(1, 5), guard.WithSlowBelow(0))
+
+	for i := 0; i < 100; i++ {
+		g.Decode(fmt.Sprintf("10.0.0.%d", i), "dnh")
+		g.Check(fmt.Sprintf("10.0.1.%d", i))
+	}
+	if store.Len() != 0 {
+		t.Errorf("expected no buckets after valid decodes, got %d", store.Len())
+	}
+
+	g.Decode("1.2.3.4", "a-b")
+	if store.Len() != 1 {
+		t.Fatalf("expected the failing caller to be stored, got %d", store.Len())
+	}
+	clock.Advance(time.Second)
+	if _, d, err := g.Decode("1.2.3.4", "dnh"); d != guard.Allow || err != nil {
+		t.Errorf("expected Allow, got %v, %v", d, err)
+	}
+	if store.Len() != 0 {
+		t.Errorf("expected the refilled bucket to be deleted, got %d", store.Len())
+	}
+
+	g.Decode("1.2.3.4", "a-b")
+	if err := g.Reset("1.2.3.4"); err != nil || store.Len() != 0 {
+		t.Errorf("expected Reset to delete the bucket, got %d buckets, %v", store.Len(), err)
+	}
+}
+
+// TestMemoryStore_MaxKeys tests that the least recently updated bucket is
+// evicted when the store is full.
+func TestMemoryStore_MaxKeys(t *testing.T) {
+	store := guard.NewMemoryStoreSize(3)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g := guard.New(yid.New(), guard.WithStore(store), guard.WithClock(clock), guard.WithLimit(0.0001, 2))
+
+	for _, key := range []string{"a", "b", "c"} {
+		g.Decode(key, "a-b")
+	}
+	g.Decode("a", "a-b") // a is now exhausted and most recently updated
+	g.Decode("d", "a-b") // evicts b
+
+	if store.Len() != 3 {
+		t.Errorf("expected 3 buckets, got %d", store.Len())
+	}
+	if d, _ := g.Check("a"); d != guard.Deny {
+		t.Errorf("expected a to stay denied, got %v", d)
+	}
+	if _, d, _ := g.Decode("b", "a-b"); d != guard.Slow && d != guard.Allow {
+		t.Errorf("expected evicted b to start over, got %v", d)
+	}
+}
@@ -0,0 +1,127 @@
+package guard
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultMaxKeys bounds the number of buckets NewMemoryStore keeps.
+const DefaultMaxKeys = 100_000
+
+// Bucket is the token bucket state of one caller key.
+// Tokens is the number of invalid attempts the caller may still make;
+// Updated is the time Tokens was last refilled.
+type Bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// Store keeps buckets by caller key.
+//
+// Update loads the bucket for key, passes it to fn (with found set to false
+// and a zero Bucket if there is none) and stores the bucket fn returns, or
+// deletes key if fn returns keep set to false. Update returns the bucket
+// either way. The guard deletes buckets that are full, since a missing
+// bucket is treated as full, so stores only hold callers that recently
+// failed. Implementations must apply Update atomically per key, so that
+// concurrent attempts from one caller are all counted.
+type Store interface {
+	Update(key string, fn func(b Bucket, found bool) (Bucket, bool)) (Bucket, error)
+}
+
+// memoryEntry is an element of MemoryStore.order.
+type memoryEntry struct {
+	key    string
+	bucket Bucket
+}
+
+// MemoryStore is an in-memory Store. It is safe for concurrent use.
+//
+// It holds at most a fixed number of buckets; when full, the bucket updated
+// least recently is evicted, which forgets that caller's failed attempts.
+// Call Prune periodically to drop idle callers before that happens.
+type MemoryStore struct {
+	mu      sync.Mutex
+	maxKeys int
+	order   *list.List // most recently updated first
+	buckets map[string]*list.Element
+}
+
+// NewMemoryStore returns an empty MemoryStore holding at most DefaultMaxKeys buckets.
+func NewMemoryStore() *MemoryStore {
+	return NewMemoryStoreSize(DefaultMaxKeys)
+}
+
+// NewMemoryStoreSize returns an empty MemoryStore holding at most maxKeys
+// buckets. Non-positive values use DefaultMaxKeys.
+func NewMemoryStoreSize(maxKeys int) *MemoryStore {
+	if maxKeys <= 0 {
+		maxKeys = DefaultMaxKeys
+	}
+	return &MemoryStore{
+		maxKeys: maxKeys,
+		order:   list.New(),
+		buckets: make(map[string]*list.Element),
+	}
+}
+
+// Update implements Store.
+func (s *MemoryStore) Update(key string, fn func(b Bucket, found bool) (Bucket, bool)) (Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, found := s.buckets[key]
+	var b Bucket
+	if found {
+		b = el.Value.(*memoryEntry).bucket
+	}
+	b, keep := fn(b, found)
+	switch {
+	case !keep:
+		if found {
+			s.remove(el)
+		}
+	case found:
+		el.Value.(*memoryEntry).bucket = b
+		s.order.MoveToFront(el)
+	default:
+		s.buckets[key] = s.order.PushFront(&memoryEntry{key: key, bucket: b})
+		if s.order.Len() > s.maxKeys {
+			s.remove(s.order.Back())
+		}
+	}
+	return b, nil
+}
+
+// remove deletes el from the store.
+func (s *MemoryStore) remove(el *list.Element) {
+	s.order.Remove(el)
+	delete(s.buckets, el.Value.(*memoryEntry).key)
+}
+
+// Prune removes buckets last updated before the given time and returns how
+// many were removed. A bucket idle for burst/rate seconds is full again, so
+// pruning it does not change any decision.
+func (s *MemoryStore) Prune(before time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for el := s.order.Back(); el != nil; {
+		prev := el.Prev()
+		if el.Value.(*memoryEntry).bucket.Updated.Before(before) {
+			s.remove(el)
+			removed++
+		}
+		el = prev
+	}
+	return removed
+}
+
+// Len returns the number of buckets in the store.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
@@ -0,0 +1,164 @@
+// Package httpx decodes yid path parameters in net/http handlers.
+//
+// It builds on the Go 1.22 ServeMux patterns: the middleware reads a named
+// wildcard with Request.PathValue, decodes it with an Encoder and stores the
+// number in the request context. Failures are answered with RFC 9457
+// application/problem+json responses: 404 for IDs that cannot exist and 400
+// for requests without the parameter.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithSecureKey("my-secret"))
+//	mux := http.NewServeMux()
+//	mux.Handle("GET /users/{id}", httpx.Middleware(enc, "id")(http.HandlerFunc(
+//		func(w http.ResponseWriter, r *http.Request) {
+//			id, _ := httpx.FromContext(r.Context(), "id")
+//			fmt.Fprintf(w, "user %d", id)
+//		})))
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// ErrMissingParam is returned when the named path parameter is absent or empty.
+var ErrMissingParam = errors.New("httpx: missing path parameter")
+
+// ContentType is the media type of problem responses.
+const ContentType = "application/problem+json"
+
+// Decoder decodes a yid string into a number. *yid.Encoder implements it.
+type Decoder interface {
+	Decode(alphanumeric string) (int64, error)
+}
+
+// Problem is an RFC 9457 problem details object.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// ProblemFunc builds the problem response for a failed decode of path parameter name.
+type ProblemFunc func(r *http.Request, name string, err error) Problem
+
+// config holds middleware configuration.
+type config struct {
+	problem ProblemFunc
+}
+
+// Option configures the middleware.
+type Option func(*config)
+
+// WithProblem sets the function that builds problem responses,
+// replacing DefaultProblem.
+func WithProblem(f ProblemFunc) Option {
+	return func(c *config) {
+		c.problem = f
+	}
+}
+
+// ctxKey is the context key for a decoded path parameter.
+type ctxKey struct {
+	name string
+}
+
+// PathID decodes the path parameter name of r with dec.
+// Returns ErrMissingParam if the parameter is absent or empty.
+func PathID(r *http.Request, dec Decoder, name string) (int64, error) {
+	value := r.PathValue(name)
+	if value == "" {
+		return 0, ErrMissingParam
+	}
+	return dec.Decode(value)
+}
+
+// NewContext returns a copy of ctx carrying id for path parameter name.
+func NewContext(ctx context.Context, name string, id int64) context.Context {
+	return context.WithValue(ctx, ctxKey{name}, id)
+}
+
+// FromContext returns the decoded ID stored by Middleware for path parameter name.
+func FromContext(ctx context.Context, name string) (int64, bool) {
+	id, ok := ctx.Value(ctxKey{name}).(int64)
+	return id, ok
+}
+
+// Middleware returns middleware that decodes path parameter name with dec and
+// stores the number in the request context (see FromContext). On failure it
+// writes a problem response and does not call the next handler.
+//
+// Wrap the handler registered on the ServeMux, not the mux itself: path
+// values are only set once a pattern has matched.
+func Middleware(dec Decoder, name string, opts ...Option) func(http.Handler) http.Handler {
+	cfg := config{problem: DefaultProblem}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := PathID(r, dec, name)
+			if err != nil {
+				WriteProblem(w, cfg.problem(r, name, err))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), name, id)))
+		})
+	}
+}
+
+// Status returns the HTTP status for a decode error: 400 Bad Request for a
+// missing path parameter, 500 Internal Server Error for an encoder built
+// with invalid options, and 404 Not Found for every other error, since no
+// ID encodes to the string (bad characters, signature or prefix, overflow,
+// wrong length, non-canonical forms, expired tokens).
+func Status(err error) int {
+	switch {
+	case errors.Is(err, ErrMissingParam):
+		return http.StatusBadRequest
+	case errors.Is(err, yid.ErrInvalidOption):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusNotFound
+	}
+}
+
+// DefaultProblem builds a problem whose status is Status(err). Only 400
+// responses include err in Detail: the others use a fixed detail, so that
+// clients cannot tell which check a guessed ID failed or how the encoder is
+// configured. Use WithProblem for richer details.
+func DefaultProblem(r *http.Request, name string, err error) Problem {
+	status := Status(err)
+	var detail string
+	switch status {
+	case http.StatusBadRequest:
+		detail = fmt.Sprintf("path parameter %q: %v", name, err)
+	case http.StatusNotFound:
+		detail = fmt.Sprintf("path parameter %q does not identify a resource", name)
+	default:
+		detail = fmt.Sprintf("path parameter %q could not be decoded", name)
+	}
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	}
+}
+
+// WriteProblem writes p as an application/problem+json response.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
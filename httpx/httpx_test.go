@@ -0,0 +1,196 @@
+package httpx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/httpx"
+)
+
+// newMux returns a mux serving GET /users/{id} through the middleware.
+func newMux(enc *yid.Encoder, opts ...httpx.Option) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{id}", httpx.Middleware(enc, "id", opts...)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			id, ok := httpx.FromContext(r.Context(), "id")
+			if !ok {
+				http.Error(w, "missing id", http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprintf(w, "user %d", id)
+		})))
+	return mux
+}
+
+// serve performs a GET request against h.
+func serve(h http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+// TestMiddleware_Decodes tests that a valid ID reaches the handler.
+func TestMiddleware_Decodes(t *testing.T) {
+	enc := yid.New(yid.WithSecureKey("secret"))
+	encoded, err := enc.Encode(12345)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec := serve(newMux(enc), "/users/"+encoded)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec.Body.String() != "user 12345" {
+		t.Errorf("expected 'user 12345', got '%s'", rec.Body)
+	}
+}
+
+// TestMiddleware_Problems tests the status and body of failure responses.
+func TestMiddleware_Problems(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"invalid character", "/users/ab-c", http.StatusNotFound},
+		{"overflow", "/users/ZZZZZZZZZZZZ", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(newMux(yid.New()), tt.path)
+			if rec.Code != tt.status {
+				t.Fatalf("expected %d, got %d", tt.status, rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != httpx.ContentType {
+				t.Errorf("expected content type %s, got %s", httpx.ContentType, ct)
+			}
+			var p httpx.Problem
+			if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Status != tt.status || p.Title != http.StatusText(tt.status) || p.Instance != tt.path {
+				t.Errorf("unexpected problem: %+v", p)
+			}
+		})
+	}
+}
+
+// TestMiddleware_GenericDetail tests that 404 and 500 problems do not reveal
+// which check failed.
+func TestMiddleware_GenericDetail(t *testing.T) {
+	signed := yid.New(yid.WithSignature([]byte("hmac-secret"), 6))
+	tests := []struct {
+		name string
+		enc  *yid.Encoder
+		path string
+	}{
+		{"invalid character", signed, "/users/ab-cdefghi"},
+		{"invalid signature", signed, "/users/dnhaaaaaa"},
+		{"overflow", yid.New(), "/users/ZZZZZZZZZZZZ"},
+		{"invalid option", yid.New(yid.WithAlphabet("aa")), "/users/a"},
+	}
+	details := make(map[int]string)
+	for _, tt := range tests {
+		rec := serve(newMux(tt.enc), tt.path)
+		var p httpx.Problem
+		if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if strings.Contains(p.Detail, "yid:") {
+			t.Errorf("%s: detail reveals the decode error: %q", tt.name, p.Detail)
+		}
+		if d, ok := details[p.Status]; ok && d != p.Detail {
+			t.Errorf("%s: expected detail %q, got %q", tt.name, d, p.Detail)
+		}
+		details[p.Status] = p.Detail
+	}
+	if len(details) != 2 {
+		t.Errorf("expected 404 and 500 problems, got %v", details)
+	}
+}
+
+// TestMiddleware_MissingParam tests that an unmatched wildcard returns 400.
+func TestMiddleware_MissingParam(t *testing.T) {
+	h := httpx.Middleware(yid.New(), "id")(http.NotFoundHandler())
+	rec := serve(h, "/users/abc")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+// TestMiddleware_WithProblem tests that problem responses are configurable.
+func TestMiddleware_WithProblem(t *testing.T) {
+	mux := newMux(yid.New(), httpx.WithProblem(func(r *http.Request, name string, err error) httpx.Problem {
+		return httpx.Problem{Type: "https://example.com/probs/bad-id", Title: "Bad ID", Status: http.StatusGone}
+	}))
+	rec := serve(mux, "/users/ab-c")
+	if rec.Code != http.StatusGone {
+		t.Fatalf("expected 410, got %d", rec.Code)
+	}
+	var p httpx.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Type != "https://example.com/probs/bad-id" {
+		t.Errorf("unexpected problem type: %s", p.Type)
+	}
+}
+
+// TestPathID tests decoding a path value directly.
+func TestPathID(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users/dnh", nil)
+	r.SetPathValue("id", "dnh")
+	id, err := httpx.PathID(r, yid.New(), "id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 12345 {
+		t.Errorf("expected 12345, got %d", id)
+	}
+	if _, err := httpx.PathID(r, yid.New(), "other"); !errors.Is(err, httpx.ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got %v", err)
+	}
+}
+
+// TestContext tests storing and retrieving IDs by parameter name.
+func TestContext(t *testing.T) {
+	ctx := httpx.NewContext(context.Background(), "org", 7)
+	if id, ok := httpx.FromContext(ctx, "org"); !ok || id != 7 {
+		t.Errorf("expected 7, got %d (%v)", id, ok)
+	}
+	if _, ok := httpx.FromContext(ctx, "user"); ok {
+		t.Error("expected no ID for other parameter")
+	}
+}
+
+// TestStatus tests the mapping from errors to HTTP statuses.
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{yid.ErrInvalidCharacter, http.StatusNotFound},
+		{yid.ErrInvalidSignature, http.StatusNotFound},
+		{yid.ErrInvalidPrefix, http.StatusNotFound},
+		{yid.ErrOverflow, http.StatusNotFound},
+		{yid.ErrNotCanonical, http.StatusNotFound},
+		{yid.ErrInvalidLength, http.StatusNotFound},
+		{yid.ErrNegativeNumber, http.StatusNotFound},
+		{yid.ErrExpired, http.StatusNotFound},
+		{fmt.Errorf("wrapped: %w", yid.ErrOverflow), http.StatusNotFound},
+		{httpx.ErrMissingParam, http.StatusBadRequest},
+		{fmt.Errorf("%w: bad alphabet", yid.ErrInvalidOption), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := httpx.Status(tt.err); got != tt.status {
+			t.Errorf("Status(%v) = %d, want %d", tt.err, got, tt.status)
+		}
+	}
+}
@@ -1,4 +1,6 @@
 // Package base62 provides core base62 encoding/decoding algorithms.
+// Encode and Decode work in base len(dictionary), so they also serve
+// custom alphabets of up to 64 characters.
 package base62
 
 import (
@@ -6,22 +8,37 @@ import (
 	"encoding/hex"
 	"errors"
 	"sort"
-	"strings"
 )
 
 // Dictionary: a-z + 0-9 + A-Z (62 characters)
 const Dictionary = "abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
+// SortableDictionary: 0-9 + A-Z + a-z (62 characters) in ASCII order, so that
+// fixed-width encodings compare byte-wise in the same order as their numbers.
+const SortableDictionary = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
 // DictLen is the length of the dictionary (62).
 const DictLen = 62
 
 // ErrInvalidCharacter is returned when decoding encounters an invalid character.
 var ErrInvalidCharacter = errors.New("base62: invalid character in input")
 
+// ErrOverflow is returned when a value does not fit in an int64.
+var ErrOverflow = errors.New("base62: value overflows int64")
+
+// ErrBelowMinimum is returned when a decoded value is below the padUp
+// offset, so that it would be negative. Encode never produces such strings.
+var ErrBelowMinimum = errors.New("base62: value below the padUp minimum")
+
 // MaxPadUp is the maximum safe padUp value to avoid integer overflow.
-// 62^10 fits in int64, but 62^11 exceeds int64 max.
+// 62^10 fits in int64, but 62^11 exceeds int64 max. The same holds for
+// dictionaries of up to 64 characters (64^10 = 2^60).
 const MaxPadUp = 11
 
+// MaxLen is the length of the longest encoding of a non-negative int64
+// (math.MaxInt64) with a 62-character dictionary.
+const MaxLen = 11
+
 // pow calculates base^exp using integer arithmetic.
 func pow(base, exp int) int64 {
 	result := int64(1)
@@ -34,65 +51,34 @@ func pow(base, exp int) int64 {
 
 // Encode converts a number to a base62 string using the given dictionary.
 // Values of padUp exceeding MaxPadUp (11) are automatically clamped to prevent overflow.
-func Encode(number int64, dictionary string, padUp int) string {
-	if padUp > 1 {
-		// Clamp padUp to MaxPadUp to prevent overflow
-		if padUp > MaxPadUp {
-			padUp = MaxPadUp
-		}
-		number += pow(DictLen, padUp-1)
-	}
-
-	if number == 0 {
-		return string(dictionary[0])
-	}
-
-	// Find the highest power of 62 that fits in the number
-	t := 0
-	temp := number
-	for temp >= DictLen {
-		temp /= DictLen
-		t++
-	}
-
-	var result strings.Builder
-	for t >= 0 {
-		bcp := pow(DictLen, t)
-		index := (number / bcp) % int64(DictLen)
-		result.WriteByte(dictionary[index])
-		number -= index * bcp
-		t--
-	}
-
-	return result.String()
+// Returns ErrOverflow if number plus the padUp offset exceeds math.MaxInt64.
+// Callers encoding repeatedly should build a Table once with NewTable.
+func Encode(number int64, dictionary string, padUp int) (string, error) {
+	t := NewTable(dictionary)
+	return t.Encode(number, padUp)
 }
 
 // Decode converts a base62 string back to a number.
 // Values of padUp exceeding MaxPadUp (11) are automatically clamped to prevent overflow.
+// Returns ErrInvalidCharacter for characters outside the dictionary,
+// ErrOverflow if the string represents a value larger than math.MaxInt64
+// and ErrBelowMinimum if the value is below the padUp offset. Callers
+// decoding repeatedly should build a Table once with NewTable.
 func Decode(alphanumeric, dictionary string, padUp int) (int64, error) {
-	var result int64
-	length := len(alphanumeric)
-	multiplier := int64(1)
-
-	for i := 0; i < length; i++ {
-		char := alphanumeric[length-1-i]
-		index := strings.IndexByte(dictionary, char)
-		if index == -1 {
-			return 0, ErrInvalidCharacter
-		}
-		result += int64(index) * multiplier
-		multiplier *= int64(DictLen)
-	}
+	t := NewTable(dictionary)
+	return t.Decode(alphanumeric, padUp)
+}
 
-	if padUp > 1 {
-		// Clamp padUp to MaxPadUp to prevent overflow
-		if padUp > MaxPadUp {
-			padUp = MaxPadUp
-		}
-		result -= pow(DictLen, padUp-1)
+// padOffset returns the value added to numbers for the given padUp and base,
+// clamping padUp to MaxPadUp to prevent overflow.
+func padOffset(padUp, base int) int64 {
+	if padUp <= 1 {
+		return 0
 	}
-
-	return result, nil
+	if padUp > MaxPadUp {
+		padUp = MaxPadUp
+	}
+	return pow(base, padUp-1)
 }
 
 // charPair holds a hash character and its corresponding dictionary character.
@@ -106,15 +92,27 @@ type charPair struct {
 // Uses SHA256 which produces 32 bytes (64 hex characters). Only the first 62 hex characters
 // are used for the 62-character dictionary; the remaining 2 characters are unused.
 func SecureDictionary(secureKey string) string {
+	return ShuffleDictionary(Dictionary, secureKey)
+}
+
+// ShuffleDictionary shuffles dictionary, of at most 64 characters, the way
+// SecureDictionary shuffles the default one: each character is paired with
+// the hex digit of SHA256(secureKey) at its position and the pairs are
+// sorted by hex digit in descending order.
+//
+// The order of pairs with the same hex digit is whatever sort.Slice produces.
+// Every ID published with a secure key depends on it, so changing it needs a
+// versioned migration rather than a fix.
+func ShuffleDictionary(dictionary, secureKey string) string {
 	hash := sha256.Sum256([]byte(secureKey))
 	hashHex := hex.EncodeToString(hash[:])
 
 	// Create pairs of hash char and dictionary char
-	pairs := make([]charPair, DictLen)
-	for i := 0; i < DictLen; i++ {
+	pairs := make([]charPair, len(dictionary))
+	for i := range pairs {
 		pairs[i] = charPair{
 			hashChar: hashHex[i],
-			dictChar: Dictionary[i],
+			dictChar: dictionary[i],
 		}
 	}
 
@@ -124,7 +122,7 @@ func SecureDictionary(secureKey string) string {
 	})
 
 	// Build result
-	result := make([]byte, DictLen)
+	result := make([]byte, len(pairs))
 	for i, p := range pairs {
 		result[i] = p.dictChar
 	}
@@ -1,6 +1,8 @@
 package base62_test
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/wow-apps/youtube-id-go/internal/base62"
@@ -18,7 +20,10 @@ func TestEncode_Basic(t *testing.T) {
 		{12345, "dnh"},
 	}
 	for _, tt := range tests {
-		result := base62.Encode(tt.input, base62.Dictionary, 0)
+		result, err := base62.Encode(tt.input, base62.Dictionary, 0)
+		if err != nil {
+			t.Fatalf("Encode(%d) error: %v", tt.input, err)
+		}
 		if result != tt.expected {
 			t.Errorf("Encode(%d) = '%s', want '%s'", tt.input, result, tt.expected)
 		}
@@ -26,8 +31,8 @@ func TestEncode_Basic(t *testing.T) {
 }
 
 func TestEncode_WithPadUp(t *testing.T) {
-	resultNoPad := base62.Encode(1, base62.Dictionary, 0)
-	resultWithPad := base62.Encode(1, base62.Dictionary, 3)
+	resultNoPad, _ := base62.Encode(1, base62.Dictionary, 0)
+	resultWithPad, _ := base62.Encode(1, base62.Dictionary, 3)
 	if resultNoPad == resultWithPad {
 		t.Error("pad_up should change output")
 	}
@@ -63,7 +68,10 @@ func TestDecode_InvalidCharacter(t *testing.T) {
 }
 
 func TestDecode_WithPadUp(t *testing.T) {
-	encoded := base62.Encode(100, base62.Dictionary, 3)
+	encoded, err := base62.Encode(100, base62.Dictionary, 3)
+	if err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
 	decoded, err := base62.Decode(encoded, base62.Dictionary, 3)
 	if err != nil {
 		t.Fatalf("Decode error: %v", err)
@@ -73,6 +81,46 @@ func TestDecode_WithPadUp(t *testing.T) {
 	}
 }
 
+func TestEncode_Overflow(t *testing.T) {
+	if _, err := base62.Encode(math.MaxInt64, base62.Dictionary, 0); err != nil {
+		t.Errorf("unexpected error without padUp: %v", err)
+	}
+	if _, err := base62.Encode(math.MaxInt64, base62.Dictionary, 3); err != base62.ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestDecode_Overflow(t *testing.T) {
+	maxEncoded, err := base62.Encode(math.MaxInt64, base62.Dictionary, 0)
+	if err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	decoded, err := base62.Decode(maxEncoded, base62.Dictionary, 0)
+	if err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if decoded != math.MaxInt64 {
+		t.Errorf("expected MaxInt64, got %d", decoded)
+	}
+
+	tests := []string{"kZviNa8fiMi", "ZZZZZZZZZZZ", "baaaaaaaaaaa"}
+	for _, input := range tests {
+		if _, err := base62.Decode(input, base62.Dictionary, 0); err != base62.ErrOverflow {
+			t.Errorf("Decode('%s'): expected ErrOverflow, got %v", input, err)
+		}
+	}
+}
+
+func TestDecode_LeadingZeros(t *testing.T) {
+	decoded, err := base62.Decode("aaaaaaaaaaaaaaaadnh", base62.Dictionary, 0)
+	if err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if decoded != 12345 {
+		t.Errorf("expected 12345, got %d", decoded)
+	}
+}
+
 func TestSecureDictionary(t *testing.T) {
 	dict1 := base62.SecureDictionary("key1")
 	dict2 := base62.SecureDictionary("key2")
@@ -105,10 +153,22 @@ func TestSecureDictionary(t *testing.T) {
 	}
 }
 
+// TestSecureDictionary_Pinned tests that the shuffle, including the order of
+// tied hex digits, matches the dictionary IDs were published with.
+func TestSecureDictionary_Pinned(t *testing.T) {
+	const want = "PKUI8AJoQDslf7C6rjTcbmMBYyzRq40dXiSEwLG35Vg12OhtnvpHaNWZkFxue9"
+	if got := base62.SecureDictionary("secret"); got != want {
+		t.Errorf("SecureDictionary(%q) = %q, want %q", "secret", got, want)
+	}
+}
+
 func TestRoundtrip(t *testing.T) {
 	testNumbers := []int64{0, 1, 10, 100, 1000, 12345, 999999, 1000000000}
 	for _, num := range testNumbers {
-		encoded := base62.Encode(num, base62.Dictionary, 0)
+		encoded, err := base62.Encode(num, base62.Dictionary, 0)
+		if err != nil {
+			t.Fatalf("Encode error for %d: %v", num, err)
+		}
 		decoded, err := base62.Decode(encoded, base62.Dictionary, 0)
 		if err != nil {
 			t.Fatalf("Decode error for %d: %v", num, err)
@@ -123,7 +183,10 @@ func TestRoundtrip_WithSecureKey(t *testing.T) {
 	dict := base62.SecureDictionary("test-key")
 	testNumbers := []int64{0, 1, 100, 12345, 999999}
 	for _, num := range testNumbers {
-		encoded := base62.Encode(num, dict, 0)
+		encoded, err := base62.Encode(num, dict, 0)
+		if err != nil {
+			t.Fatalf("Encode error for %d: %v", num, err)
+		}
 		decoded, err := base62.Decode(encoded, dict, 0)
 		if err != nil {
 			t.Fatalf("Decode error for %d: %v", num, err)
@@ -142,3 +205,83 @@ func TestDictionaryConstant(t *testing.T) {
 		t.Errorf("DictLen = %d, want 62", base62.DictLen)
 	}
 }
+
+// FuzzDecode checks that Decode never panics, never returns a negative
+// number and that Encode reverses it.
+func FuzzDecode(f *testing.F) {
+	f.Add("dnh", 0)
+	f.Add("kZviNa8fiMh", 0)
+	f.Add("a", base62.MaxPadUp)
+
+	f.Fuzz(func(t *testing.T, s string, padUp int) {
+		n, err := base62.Decode(s, base62.Dictionary, padUp)
+		if err != nil {
+			switch err {
+			case base62.ErrInvalidCharacter, base62.ErrOverflow, base62.ErrBelowMinimum:
+			default:
+				t.Fatalf("Decode(%q) returned unexpected error: %v", s, err)
+			}
+			return
+		}
+		if n < 0 {
+			t.Fatalf("Decode(%q) = %d without error", s, n)
+		}
+		encoded, err := base62.Encode(n, base62.Dictionary, padUp)
+		if err != nil {
+			t.Fatalf("Encode(%d) error: %v", n, err)
+		}
+		again, err := base62.Decode(encoded, base62.Dictionary, padUp)
+		if err != nil || again != n {
+			t.Errorf("roundtrip failed: %q -> %d -> %q -> %d (%v)", s, n, encoded, again, err)
+		}
+	})
+}
+
+// FuzzSecureDictionary checks that every key yields a permutation of Dictionary.
+func FuzzSecureDictionary(f *testing.F) {
+	f.Add("")
+	f.Add("secret")
+	f.Add("ключ")
+
+	f.Fuzz(func(t *testing.T, key string) {
+		dict := base62.SecureDictionary(key)
+		if len(dict) != base62.DictLen {
+			t.Fatalf("dictionary length = %d, want %d", len(dict), base62.DictLen)
+		}
+		var seen [256]bool
+		for i := 0; i < len(dict); i++ {
+			c := dict[i]
+			if seen[c] || strings.IndexByte(base62.Dictionary, c) == -1 {
+				t.Fatalf("SecureDictionary(%q) = %q is not a permutation of Dictionary", key, dict)
+			}
+			seen[c] = true
+		}
+	})
+}
+
+func TestSortableDictionary(t *testing.T) {
+	if len(base62.SortableDictionary) != base62.DictLen {
+		t.Errorf("SortableDictionary length = %d, want %d", len(base62.SortableDictionary), base62.DictLen)
+	}
+	for i := 1; i < len(base62.SortableDictionary); i++ {
+		if base62.SortableDictionary[i-1] >= base62.SortableDictionary[i] {
+			t.Errorf("SortableDictionary is not in ascending order at %d", i)
+		}
+	}
+	maxEncoded, err := base62.Encode(math.MaxInt64, base62.SortableDictionary, 0)
+	if err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if len(maxEncoded) != base62.MaxLen {
+		t.Errorf("MaxInt64 encodes to %d characters, want MaxLen %d", len(maxEncoded), base62.MaxLen)
+	}
+}
+
+// BenchmarkSecureDictionary measures shuffling the dictionary with a key,
+// which New does once per encoder.
+func BenchmarkSecureDictionary(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base62.SecureDictionary("secret")
+	}
+}
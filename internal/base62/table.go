@@ -0,0 +1,82 @@
+package base62
+
+import "math"
+
+// Table holds the forward (digit to character) and reverse (character to
+// digit) lookups of a dictionary, so that encoding and decoding need no
+// scans of the dictionary string. The zero Table is not usable; build one
+// with NewTable. A Table is immutable and safe for concurrent use.
+type Table struct {
+	base   int64
+	digits [64]byte
+	index  [256]int8
+}
+
+// NewTable builds the lookup tables for dictionary, which must have 2 to 64
+// distinct characters.
+func NewTable(dictionary string) Table {
+	t := Table{base: int64(len(dictionary))}
+	for i := range t.index {
+		t.index[i] = -1
+	}
+	for i := 0; i < len(dictionary); i++ {
+		t.digits[i] = dictionary[i]
+		t.index[dictionary[i]] = int8(i)
+	}
+	return t
+}
+
+// Digit returns the value of c, or -1 if c is not in the dictionary.
+func (t *Table) Digit(c byte) int {
+	return int(t.index[c])
+}
+
+// Char returns the character for digit d, which must be below the base.
+func (t *Table) Char(d int) byte {
+	return t.digits[d]
+}
+
+// Encode converts a number to a string in the table's dictionary, with the
+// same padUp handling and errors as the package-level Encode.
+func (t *Table) Encode(number int64, padUp int) (string, error) {
+	if offset := padOffset(padUp, int(t.base)); offset > 0 {
+		if number > math.MaxInt64-offset {
+			return "", ErrOverflow
+		}
+		number += offset
+	}
+
+	// A base-2 int64 has at most 63 digits.
+	var buf [64]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = t.digits[number%t.base]
+		number /= t.base
+		if number == 0 {
+			break
+		}
+	}
+	return string(buf[i:]), nil
+}
+
+// Decode converts a string in the table's dictionary back to a number, with
+// the same padUp handling and errors as the package-level Decode.
+func (t *Table) Decode(alphanumeric string, padUp int) (int64, error) {
+	var result int64
+	for i := 0; i < len(alphanumeric); i++ {
+		index := int64(t.index[alphanumeric[i]])
+		if index < 0 {
+			return 0, ErrInvalidCharacter
+		}
+		if result > (math.MaxInt64-index)/t.base {
+			return 0, ErrOverflow
+		}
+		result = result*t.base + index
+	}
+	result -= padOffset(padUp, int(t.base))
+	if result < 0 {
+		return 0, ErrBelowMinimum
+	}
+	return result, nil
+}
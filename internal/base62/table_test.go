@@ -0,0 +1,150 @@
+package base62_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/wow-apps/youtube-id-go/internal/base62"
+)
+
+// tableDictionaries covers the default, sortable, shuffled and non-62 dictionaries.
+var tableDictionaries = []string{
+	base62.Dictionary,
+	base62.SortableDictionary,
+	base62.SecureDictionary("secret"),
+	"01",
+	"0123456789ABCDEFGHJKMNPQRSTVWXYZ",
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_",
+}
+
+// referenceEncode encodes by repeated division with the dictionary string,
+// independently of Table.
+func referenceEncode(n int64, dict string, padUp int) (string, bool) {
+	base := int64(len(dict))
+	if padUp > base62.MaxPadUp {
+		padUp = base62.MaxPadUp
+	}
+	if padUp > 1 {
+		offset := int64(1)
+		for i := 1; i < padUp; i++ {
+			offset *= base
+		}
+		if n > math.MaxInt64-offset {
+			return "", false
+		}
+		n += offset
+	}
+	out := ""
+	for {
+		out = string(dict[n%base]) + out
+		n /= base
+		if n == 0 {
+			return out, true
+		}
+	}
+}
+
+// TestTable_MatchesReference tests Table against a straightforward
+// implementation, and Encode and Decode against Table.
+func TestTable_MatchesReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	numbers := []int64{0, 1, 61, 62, 63, 64, 12345, math.MaxInt64 - 1, math.MaxInt64}
+	for i := 0; i < 500; i++ {
+		numbers = append(numbers, rng.Int63()>>uint(rng.Intn(63)))
+	}
+	for _, dict := range tableDictionaries {
+		table := base62.NewTable(dict)
+		for _, padUp := range []int{0, 1, 3, base62.MaxPadUp} {
+			for _, n := range numbers {
+				want, ok := referenceEncode(n, dict, padUp)
+				got, err := table.Encode(n, padUp)
+				if ok && (got != want || err != nil) || !ok && !errors.Is(err, base62.ErrOverflow) {
+					t.Fatalf("%s: Encode(%d, %d) = %q, %v, want %q (ok %t)", dict, n, padUp, got, err, want, ok)
+				}
+				if fn, fnErr := base62.Encode(n, dict, padUp); fn != got || !errors.Is(fnErr, err) {
+					t.Fatalf("%s: base62.Encode(%d, %d) = %q, %v, want %q, %v", dict, n, padUp, fn, fnErr, got, err)
+				}
+				if err != nil {
+					continue
+				}
+				back, err := table.Decode(got, padUp)
+				if err != nil || back != n {
+					t.Fatalf("%s: Decode(%q, %d) = %d, %v, want %d", dict, got, padUp, back, err, n)
+				}
+				if fn, err := base62.Decode(got, dict, padUp); err != nil || fn != n {
+					t.Fatalf("%s: base62.Decode(%q, %d) = %d, %v, want %d", dict, got, padUp, fn, err, n)
+				}
+			}
+		}
+	}
+}
+
+// TestTable_DecodeErrors tests invalid characters and overflow.
+func TestTable_DecodeErrors(t *testing.T) {
+	table := base62.NewTable(base62.Dictionary)
+	for _, input := range []string{"ab-c", "é", "a b", "\x00"} {
+		if _, err := table.Decode(input, 0); !errors.Is(err, base62.ErrInvalidCharacter) {
+			t.Errorf("Decode(%q): expected ErrInvalidCharacter, got %v", input, err)
+		}
+	}
+	if _, err := table.Decode("ZZZZZZZZZZZZ", 0); !errors.Is(err, base62.ErrOverflow) {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+	if table.Digit('Z') != 61 || table.Digit('-') != -1 {
+		t.Errorf("unexpected digits %d, %d", table.Digit('Z'), table.Digit('-'))
+	}
+}
+
+// benchmarkNumbers are the values encoded by the benchmarks.
+var benchmarkNumbers = []int64{0, 12345, 1 << 33, math.MaxInt64}
+
+// BenchmarkEncode measures Encode, which builds a Table per call.
+func BenchmarkEncode(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base62.Encode(benchmarkNumbers[i%len(benchmarkNumbers)], base62.Dictionary, 0)
+	}
+}
+
+// BenchmarkTable_Encode measures Table.Encode for comparison with BenchmarkEncode.
+func BenchmarkTable_Encode(b *testing.B) {
+	table := base62.NewTable(base62.Dictionary)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		table.Encode(benchmarkNumbers[i%len(benchmarkNumbers)], 0)
+	}
+}
+
+// BenchmarkDecode measures Decode, which builds a Table per call.
+func BenchmarkDecode(b *testing.B) {
+	inputs := encodedNumbers(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base62.Decode(inputs[i%len(inputs)], base62.Dictionary, 0)
+	}
+}
+
+// BenchmarkTable_Decode measures Table.Decode for comparison with BenchmarkDecode.
+func BenchmarkTable_Decode(b *testing.B) {
+	inputs := encodedNumbers(b)
+	table := base62.NewTable(base62.Dictionary)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		table.Decode(inputs[i%len(inputs)], 0)
+	}
+}
+
+// encodedNumbers returns benchmarkNumbers encoded with the default dictionary.
+func encodedNumbers(b *testing.B) []string {
+	out := make([]string, len(benchmarkNumbers))
+	for i, n := range benchmarkNumbers {
+		s, err := base62.Encode(n, base62.Dictionary, 0)
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+		out[i] = s
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+// Package convert copies values between structurally similar types while
+// converting selected ID fields between int64 and encoded strings.
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrTypeMismatch is returned when paired fields have incompatible types.
+var ErrTypeMismatch = errors.New("incompatible field types")
+
+// Codec encodes and decodes IDs.
+type Codec interface {
+	EncodeRaw(number int64) (string, error)
+	Decode(alphanumeric string) (int64, error)
+}
+
+// Direction selects which way ID fields are converted.
+type Direction int
+
+const (
+	// Encode converts int64 fields into strings.
+	Encode Direction = iota
+	// Decode converts string fields into int64.
+	Decode
+)
+
+// FieldFunc returns the Codec for a pair of same-named struct fields, or nil
+// if the fields do not hold IDs.
+type FieldFunc func(dst, src reflect.StructField) (Codec, error)
+
+// Copy copies src into dst, which must be settable. Struct fields are paired
+// by name; only exported fields are visited. ID fields selected by field are
+// encoded or decoded, including behind pointers and inside slices and maps.
+// Empty strings decode to 0.
+// path names the root value in error messages.
+func Copy(dst, src reflect.Value, dir Direction, field FieldFunc, path string) error {
+	return walk(dst, src, dir, field, nil, path)
+}
+
+// walk copies src into dst. codec is non-nil inside an ID field.
+func walk(dst, src reflect.Value, dir Direction, field FieldFunc, codec Codec, path string) error {
+	if !src.IsValid() {
+		return nil
+	}
+
+	if codec != nil {
+		switch {
+		case dir == Encode && src.Kind() == reflect.Int64 && dst.Kind() == reflect.String:
+			s, err := codec.EncodeRaw(src.Int())
+			if err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			dst.SetString(s)
+			return nil
+		case dir == Decode && src.Kind() == reflect.String && dst.Kind() == reflect.Int64:
+			if src.String() == "" {
+				// An unset ID stays unset.
+				dst.SetInt(0)
+				return nil
+			}
+			n, err := codec.Decode(src.String())
+			if err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			dst.SetInt(n)
+			return nil
+		}
+	}
+
+	switch {
+	case dst.Kind() == reflect.Pointer:
+		if src.Kind() == reflect.Pointer {
+			if src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+			src = src.Elem()
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return walk(dst.Elem(), src, dir, field, codec, path)
+	case src.Kind() == reflect.Pointer:
+		if src.IsNil() {
+			return nil
+		}
+		return walk(dst, src.Elem(), dir, field, codec, path)
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Struct:
+		return walkStruct(dst, src, dir, field, path)
+	case dst.Kind() == reflect.Slice && src.Kind() == reflect.Slice:
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+		out := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := walk(out.Index(i), src.Index(i), dir, field, codec, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+		return nil
+	case dst.Kind() == reflect.Array && src.Kind() == reflect.Array && dst.Len() == src.Len():
+		for i := 0; i < src.Len(); i++ {
+			if err := walk(dst.Index(i), src.Index(i), dir, field, codec, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case dst.Kind() == reflect.Map && src.Kind() == reflect.Map && dst.Type().Key() == src.Type().Key():
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+		out := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := walk(elem, iter.Value(), dir, field, codec, fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+			out.SetMapIndex(iter.Key(), elem)
+		}
+		dst.Set(out)
+		return nil
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+		return nil
+	default:
+		return fmt.Errorf("%w: field %s: %s to %s", ErrTypeMismatch, path, src.Type(), dst.Type())
+	}
+}
+
+// walkStruct copies the exported fields of src into the same-named fields of dst.
+func walkStruct(dst, src reflect.Value, dir Direction, field FieldFunc, path string) error {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		dstField := t.Field(i)
+		if !dstField.IsExported() {
+			continue
+		}
+		srcField, ok := src.Type().FieldByName(dstField.Name)
+		if !ok || !srcField.IsExported() || len(srcField.Index) != 1 {
+			continue
+		}
+		codec, err := field(dstField, srcField)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", path, dstField.Name, err)
+		}
+		err = walk(dst.Field(i), src.Field(srcField.Index[0]), dir, field, codec, path+"."+dstField.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package convert_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/wow-apps/youtube-id-go/internal/convert"
+)
+
+// decimal is a Codec that renders IDs in base 10.
+type decimal struct{}
+
+func (decimal) EncodeRaw(number int64) (string, error) {
+	if number < 0 {
+		return "", errors.New("negative")
+	}
+	return strconv.FormatInt(number, 10), nil
+}
+
+func (decimal) Decode(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// byName selects fields named ID.
+func byName(dst, _ reflect.StructField) (convert.Codec, error) {
+	if dst.Name == "ID" {
+		return decimal{}, nil
+	}
+	return nil, nil
+}
+
+type internal struct {
+	ID    [2]int64
+	Child *internal
+	Note  string
+}
+
+type public struct {
+	ID    [2]string
+	Child *public
+	Note  string
+	Extra int
+}
+
+func TestCopy_Roundtrip(t *testing.T) {
+	src := internal{ID: [2]int64{1, 2}, Child: &internal{ID: [2]int64{3, 4}}, Note: "x"}
+
+	var pub public
+	if err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.ValueOf(src), convert.Encode, byName, "internal"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pub.ID != [2]string{"1", "2"} || pub.Child.ID != [2]string{"3", "4"} || pub.Note != "x" {
+		t.Errorf("unexpected result: %+v", pub)
+	}
+
+	var back internal
+	if err := convert.Copy(reflect.ValueOf(&back).Elem(), reflect.ValueOf(&pub), convert.Decode, byName, "public"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(back, src) {
+		t.Errorf("expected %+v, got %+v", src, back)
+	}
+}
+
+func TestCopy_EmptyStringDecodesToZero(t *testing.T) {
+	var back internal
+	src := public{ID: [2]string{"", "5"}}
+	if err := convert.Copy(reflect.ValueOf(&back).Elem(), reflect.ValueOf(src), convert.Decode, byName, "public"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if back.ID != [2]int64{0, 5} {
+		t.Errorf("unexpected result: %v", back.ID)
+	}
+}
+
+func TestCopy_Errors(t *testing.T) {
+	var pub public
+	err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.ValueOf(internal{ID: [2]int64{-1, 0}}), convert.Encode, byName, "internal")
+	if err == nil || err.Error() != "field internal.ID[0]: negative" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	failing := func(reflect.StructField, reflect.StructField) (convert.Codec, error) {
+		return nil, errors.New("boom")
+	}
+	if err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.ValueOf(internal{}), convert.Encode, failing, "internal"); err == nil {
+		t.Error("expected error from field func")
+	}
+
+	var n int
+	if err := convert.Copy(reflect.ValueOf(&n).Elem(), reflect.ValueOf("x"), convert.Encode, byName, "n"); !errors.Is(err, convert.ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+type collections struct {
+	ID    []int64
+	Map   map[string]*internal
+	Ptr   *int64
+	Slice []internal
+}
+
+type publicCollections struct {
+	ID    []string
+	Map   map[string]*public
+	Ptr   *int64
+	Slice []public
+}
+
+func TestCopy_Collections(t *testing.T) {
+	n := int64(9)
+	src := &collections{
+		ID:    []int64{7},
+		Map:   map[string]*internal{"a": {ID: [2]int64{1, 1}}, "b": nil},
+		Ptr:   &n,
+		Slice: []internal{{Note: "s"}},
+	}
+	var pub publicCollections
+	if err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.ValueOf(src), convert.Encode, byName, "c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pub.ID[0] != "7" || pub.Map["a"].ID[0] != "1" || pub.Map["b"] != nil || *pub.Ptr != 9 || pub.Slice[0].Note != "s" {
+		t.Errorf("unexpected result: %+v", pub)
+	}
+
+	pub = publicCollections{ID: []string{"x"}, Map: map[string]*public{}}
+	var nilSrc *collections
+	if err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.ValueOf(nilSrc), convert.Encode, byName, "c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.ValueOf(collections{}), convert.Encode, byName, "c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pub.ID != nil || pub.Map != nil || pub.Ptr != nil {
+		t.Errorf("expected nil collections, got %+v", pub)
+	}
+
+	if err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.Value{}, convert.Encode, byName, "c"); err != nil {
+		t.Errorf("unexpected error for invalid source: %v", err)
+	}
+
+	bad := collections{Map: map[string]*internal{"a": {ID: [2]int64{-1, 0}}}}
+	if err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.ValueOf(bad), convert.Encode, byName, "c"); err == nil {
+		t.Error("expected error from map value")
+	}
+	bad = collections{Slice: []internal{{ID: [2]int64{-1, 0}}}}
+	if err := convert.Copy(reflect.ValueOf(&pub).Elem(), reflect.ValueOf(bad), convert.Encode, byName, "c"); err == nil {
+		t.Error("expected error from slice element")
+	}
+}
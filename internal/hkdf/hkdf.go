@@ -0,0 +1,46 @@
+// Package hkdf implements the HMAC-based key derivation function of RFC 5869
+// with SHA-256.
+package hkdf
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// MaxLength is the longest output Key can produce (255 hash blocks).
+const MaxLength = 255 * sha256.Size
+
+// Extract returns a pseudorandom key from secret and an optional salt.
+func Extract(secret, salt []byte) []byte {
+	if len(salt) == 0 {
+		salt = make([]byte, sha256.Size)
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(secret)
+	return mac.Sum(nil)
+}
+
+// Expand stretches the pseudorandom key prk into length bytes bound to info.
+// length is clamped to MaxLength.
+func Expand(prk, info []byte, length int) []byte {
+	if length > MaxLength {
+		length = MaxLength
+	}
+	out := make([]byte, 0, length+sha256.Size)
+	mac := hmac.New(sha256.New, prk)
+	var block []byte
+	for counter := byte(1); len(out) < length; counter++ {
+		mac.Reset()
+		mac.Write(block)
+		mac.Write(info)
+		mac.Write([]byte{counter})
+		block = mac.Sum(nil)
+		out = append(out, block...)
+	}
+	return out[:length]
+}
+
+// Key derives length bytes from secret, salt and info (Extract then Expand).
+func Key(secret, salt, info []byte, length int) []byte {
+	return Expand(Extract(secret, salt), info, length)
+}
@@ -0,0 +1,63 @@
+package hkdf_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/wow-apps/youtube-id-go/internal/hkdf"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("bad hex: %v", err)
+	}
+	return b
+}
+
+// TestKey_RFC5869 checks the SHA-256 test cases from RFC 5869 appendix A.
+func TestKey_RFC5869(t *testing.T) {
+	tests := []struct {
+		name, ikm, salt, info, prk, okm string
+		length                          int
+	}{
+		{
+			name:   "case 1",
+			ikm:    "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+			salt:   "000102030405060708090a0b0c",
+			info:   "f0f1f2f3f4f5f6f7f8f9",
+			length: 42,
+			prk:    "077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5",
+			okm:    "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+		},
+		{
+			name:   "case 3",
+			ikm:    "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+			length: 42,
+			prk:    "19ef24a32c717b167f33a91d6f648bdf96596776afdb6377ac434c1c293ccb04",
+			okm:    "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prk := hkdf.Extract(mustHex(t, tt.ikm), mustHex(t, tt.salt))
+			if !bytes.Equal(prk, mustHex(t, tt.prk)) {
+				t.Errorf("PRK = %x, want %s", prk, tt.prk)
+			}
+			okm := hkdf.Key(mustHex(t, tt.ikm), mustHex(t, tt.salt), mustHex(t, tt.info), tt.length)
+			if !bytes.Equal(okm, mustHex(t, tt.okm)) {
+				t.Errorf("OKM = %x, want %s", okm, tt.okm)
+			}
+		})
+	}
+}
+
+// TestExpand_MaxLength tests that the output length is clamped.
+func TestExpand_MaxLength(t *testing.T) {
+	out := hkdf.Expand(make([]byte, 32), nil, hkdf.MaxLength+1)
+	if len(out) != hkdf.MaxLength {
+		t.Errorf("length = %d, want %d", len(out), hkdf.MaxLength)
+	}
+}
@@ -0,0 +1,98 @@
+package yid
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// ID pairs a number with the encoder that encodes it, so that logs show both
+// forms. ID implements slog.LogValuer and fmt.Stringer.
+//
+// Example:
+//
+//	slog.Info("user created", "user", enc.ID(12345))
+//	// -> user.id=dnh user.number=12345
+type ID struct {
+	Number  int64
+	Encoder *Encoder
+}
+
+// ID returns number paired with e.
+func (e *Encoder) ID(number int64) ID {
+	return ID{Number: number, Encoder: e}
+}
+
+// encoder returns the ID's encoder, or the default encoder if none is set.
+func (id ID) encoder() *Encoder {
+	if id.Encoder == nil {
+		return New()
+	}
+	return id.Encoder
+}
+
+// String returns the encoded form, or a placeholder if the number cannot be encoded.
+func (id ID) String() string {
+	s, err := id.encoder().Encode(id.Number)
+	if err != nil {
+		return fmt.Sprintf("!INVALID(%v)", err)
+	}
+	return s
+}
+
+// LogValue implements slog.LogValuer. It logs a group with the encoded
+// form under "id" and the number under "number"; if the number cannot be
+// encoded, "id" is replaced by "error".
+func (id ID) LogValue() slog.Value {
+	s, err := id.encoder().Encode(id.Number)
+	if err != nil {
+		return slog.GroupValue(slog.String("error", err.Error()), slog.Int64("number", id.Number))
+	}
+	return slog.GroupValue(slog.String("id", s), slog.Int64("number", id.Number))
+}
+
+// String describes the encoder's configuration. The dictionary, secure key
+// and signature key are never included, so encoders are safe to print and log.
+func (e *Encoder) String() string {
+	if e == nil {
+		return "yid.Encoder(nil)"
+	}
+	return fmt.Sprintf("yid.Encoder{name: %q, prefix: %q, padUp: %d, transform: %s, sortable: %t, secureKey: %s, signature: %d}",
+		e.name, e.prefix, e.padUp, e.transform, e.sortable, redacted(e.keyed), e.signatureChars())
+}
+
+// GoString implements fmt.GoStringer so that %#v does not dump the dictionary.
+func (e *Encoder) GoString() string {
+	return e.String()
+}
+
+// LogValue implements slog.LogValuer with the same fields as String.
+func (e *Encoder) LogValue() slog.Value {
+	if e == nil {
+		return slog.StringValue("yid.Encoder(nil)")
+	}
+	return slog.GroupValue(
+		slog.String("name", e.name),
+		slog.String("prefix", e.prefix),
+		slog.Int("padUp", e.padUp),
+		slog.String("transform", e.transform.String()),
+		slog.Bool("sortable", e.sortable),
+		slog.String("secureKey", redacted(e.keyed)),
+		slog.Int("signature", e.signatureChars()),
+	)
+}
+
+// signatureChars returns the number of signature characters, or 0 if unsigned.
+func (e *Encoder) signatureChars() int {
+	if !e.signed() {
+		return 0
+	}
+	return e.signChars
+}
+
+// redacted describes whether a secret is set without revealing it.
+func redacted(set bool) string {
+	if set {
+		return "[REDACTED]"
+	}
+	return "none"
+}
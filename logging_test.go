@@ -0,0 +1,135 @@
+package yid_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestID_String tests the encoded form of an ID.
+func TestID_String(t *testing.T) {
+	if s := yid.New().ID(12345).String(); s != "dnh" {
+		t.Errorf("expected dnh, got %q", s)
+	}
+	if s := (yid.ID{Number: 12345}).String(); s != "dnh" {
+		t.Errorf("expected default encoder to give dnh, got %q", s)
+	}
+	if s := yid.New().ID(-1).String(); !strings.HasPrefix(s, "!INVALID(") {
+		t.Errorf("expected invalid placeholder, got %q", s)
+	}
+}
+
+// TestID_LogValue tests that both forms of an ID are logged.
+func TestID_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime}))
+	logger.Info("created", "user", yid.New().ID(12345), "bad", yid.New().ID(-1))
+
+	out := buf.String()
+	for _, want := range []string{"user.id=dnh", "user.number=12345", "bad.error=", "bad.number=-1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
+
+// TestEncoder_Redacted tests that printing or logging an encoder never reveals its secrets.
+func TestEncoder_Redacted(t *testing.T) {
+	enc := yid.New(yid.WithName("user"), yid.WithSecureKey("top-secret"), yid.WithSignature([]byte("hmac-secret"), 6))
+	dict := yid.New(yid.WithSecureKey("top-secret"))
+	raw, _ := dict.EncodeRaw(12345678901)
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("config", "encoder", enc)
+
+	outputs := map[string]string{
+		"%v":   fmt.Sprintf("%v", enc),
+		"%+v":  fmt.Sprintf("%+v", enc),
+		"%#v":  fmt.Sprintf("%#v", enc),
+		"%s":   fmt.Sprintf("%s", enc),
+		"slog": buf.String(),
+	}
+	for format, out := range outputs {
+		for _, secret := range []string{"top-secret", "hmac-secret", raw} {
+			if strings.Contains(out, secret) {
+				t.Errorf("%s output %q leaks %q", format, out, secret)
+			}
+		}
+		if !strings.Contains(out, "user") || !strings.Contains(out, "REDACTED") {
+			t.Errorf("%s output %q does not describe the encoder", format, out)
+		}
+	}
+	if strings.Contains(outputs["%#v"], "abcdefghij") {
+		t.Errorf("%%#v output leaks the dictionary: %q", outputs["%#v"])
+	}
+}
+
+// TestEncoder_LogValueCustomAlphabet tests that an encoder with a custom
+// alphabet and no secure key is not reported as keyed.
+func TestEncoder_LogValueCustomAlphabet(t *testing.T) {
+	tests := map[string]struct {
+		enc      *yid.Encoder
+		expected string
+	}{
+		"unkeyed": {yid.New(yid.WithAlphabet("0123456789abcdef")), "none"},
+		"keyed":   {yid.New(yid.WithAlphabet("0123456789abcdef"), yid.WithSecureKey("k")), "[REDACTED]"},
+		"cleared": {yid.New(yid.WithSecureKey("k"), yid.WithSecureKey("")), "none"},
+	}
+	for name, tt := range tests {
+		var got string
+		for _, attr := range tt.enc.LogValue().Group() {
+			if attr.Key == "secureKey" {
+				got = attr.Value.String()
+			}
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected secureKey=%s, got %q", name, tt.expected, got)
+		}
+	}
+}
+
+// TestEncoder_String tests the description of an encoder.
+func TestEncoder_String(t *testing.T) {
+	tests := []struct {
+		enc      *yid.Encoder
+		expected string
+	}{
+		{yid.New(), `yid.Encoder{name: "", prefix: "", padUp: 0, transform: none, sortable: false, secureKey: none, signature: 0}`},
+		{yid.New(yid.WithSortable(), yid.WithTransform(yid.TransformUpper)), `yid.Encoder{name: "", prefix: "", padUp: 0, transform: upper, sortable: true, secureKey: none, signature: 0}`},
+		{yid.New(yid.WithName("org"), yid.WithPadUp(3), yid.WithSecureKey("k"), yid.WithSignature([]byte("s"), 4)), `yid.Encoder{name: "org", prefix: "", padUp: 3, transform: none, sortable: false, secureKey: [REDACTED], signature: 4}`},
+		{yid.New(yid.WithAlphabet("0123456789abcdef")), `yid.Encoder{name: "", prefix: "", padUp: 0, transform: none, sortable: false, secureKey: none, signature: 0}`},
+		{nil, "yid.Encoder(nil)"},
+	}
+	for _, tt := range tests {
+		if got := tt.enc.String(); got != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+// TestTransform_String tests transform names.
+func TestTransform_String(t *testing.T) {
+	tests := map[yid.Transform]string{
+		yid.TransformNone:  "none",
+		yid.TransformUpper: "upper",
+		yid.TransformLower: "lower",
+		yid.Transform(7):   "Transform(7)",
+	}
+	for tr, expected := range tests {
+		if tr.String() != expected {
+			t.Errorf("expected %q, got %q", expected, tr.String())
+		}
+	}
+}
+
+// dropTime removes the time attribute for deterministic output.
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
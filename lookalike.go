@@ -0,0 +1,135 @@
+package yid
+
+import (
+	"strings"
+)
+
+// lookalikeGroups lists characters that are easily misread as each other,
+// most preferred first. SafeAlphabet keeps the first character of each group
+// that the alphabet has; WithLookalikes decodes the others as that character.
+var lookalikeGroups = []string{
+	"0Oo",
+	"1lI",
+	"2Zz",
+	"5Ss",
+	"6G",
+	"8B",
+	"9gq",
+}
+
+// ConfusablePair is two alphabet characters that are easily misread as each other.
+type ConfusablePair struct {
+	A, B byte
+}
+
+// String returns the pair as "A/B".
+func (p ConfusablePair) String() string {
+	return string([]byte{p.A, '/', p.B})
+}
+
+// AlphabetReport describes an alphabet for WithAlphabet.
+type AlphabetReport struct {
+	// Length is the number of characters, which is the encoding base.
+	Length int
+	// CaseInsensitive reports whether all letters have the same case, so
+	// that Decode accepts either case.
+	CaseInsensitive bool
+	// Confusables lists the pairs of alphabet characters that are easily
+	// misread as each other, such as 0/O and 1/l.
+	Confusables []ConfusablePair
+	// Safe is the alphabet without confusable characters (see SafeAlphabet).
+	Safe string
+	// Err is the error New would report for the alphabet, or nil.
+	Err error
+}
+
+// AnalyzeAlphabet reports the visually confusable characters of alphabet.
+//
+// Example:
+//
+//	r := yid.AnalyzeAlphabet(yid.DefaultAlphabet)
+//	r.Confusables // -> [0/O 0/o O/o 1/l 1/I l/I ...]
+//	r.Safe        // -> "abcdefhijkmnprtuvwxy0123456789ACDEFHJKLMNPQRTUVWXY"
+func AnalyzeAlphabet(alphabet string) AlphabetReport {
+	r := AlphabetReport{
+		Length:          len(alphabet),
+		CaseInsensitive: caseOf(alphabet) != mixedCase,
+		Safe:            SafeAlphabet(alphabet),
+		Err:             validateAlphabet(alphabet),
+	}
+	for _, group := range lookalikeGroups {
+		for i := 0; i < len(group); i++ {
+			if strings.IndexByte(alphabet, group[i]) < 0 {
+				continue
+			}
+			for j := i + 1; j < len(group); j++ {
+				if strings.IndexByte(alphabet, group[j]) >= 0 {
+					r.Confusables = append(r.Confusables, ConfusablePair{group[i], group[j]})
+				}
+			}
+		}
+	}
+	return r
+}
+
+// SafeAlphabet returns alphabet without confusable characters: of each group
+// of look-alikes (0/O/o, 1/l/I, 2/Z/z, 5/S/s, 6/G, 8/B, 9/g/q) it keeps only
+// the first one the alphabet has, preferring digits. The order of the
+// remaining characters is unchanged.
+//
+// Example:
+//
+//	yid.SafeAlphabet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ") // -> "0123456789ACDEFHJKLMNPQRTUVWXY"
+func SafeAlphabet(alphabet string) string {
+	var b strings.Builder
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if canonical, ok := lookalikeOf(alphabet, c); ok && canonical != c {
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// lookalikeOf returns the preferred character of c's look-alike group that
+// alphabet has, if c is in a group and the alphabet has one of its members.
+func lookalikeOf(alphabet string, c byte) (byte, bool) {
+	for _, group := range lookalikeGroups {
+		if strings.IndexByte(group, c) < 0 {
+			continue
+		}
+		for i := 0; i < len(group); i++ {
+			if strings.IndexByte(alphabet, group[i]) >= 0 {
+				return group[i], true
+			}
+		}
+	}
+	return 0, false
+}
+
+// WithSafeAlphabet removes confusable characters from the alphabet (the
+// WithAlphabet one, or DefaultAlphabet) with SafeAlphabet, so that IDs can
+// be read aloud and typed without mix-ups. Encoding works in the smaller
+// base, so IDs may be slightly longer. Sortable mode ignores it.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithSafeAlphabet(), yid.WithLookalikes())
+//	enc.Encode(12345) // -> "eVU"
+//	enc.Decode("eVU") // -> 12345
+func WithSafeAlphabet() Option {
+	return func(c *config) {
+		c.safe = true
+	}
+}
+
+// WithLookalikes makes Decode, DecodeExpiring and Validate accept characters
+// that are missing from the alphabet as the look-alike the alphabet has,
+// such as O for 0 or l for 1 with WithSafeAlphabet. It also enables the
+// whitespace removal of WithGrouping. Encode output is unchanged.
+func WithLookalikes() Option {
+	return func(c *config) {
+		c.lookalikes = true
+	}
+}
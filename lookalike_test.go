@@ -0,0 +1,123 @@
+package yid_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestAnalyzeAlphabet tests the report for several alphabets.
+func TestAnalyzeAlphabet(t *testing.T) {
+	tests := []struct {
+		alphabet        string
+		confusables     string
+		safe            string
+		caseInsensitive bool
+	}{
+		{yid.DefaultAlphabet, "0/O 0/o O/o 1/l 1/I l/I 2/Z 2/z Z/z 5/S 5/s S/s 6/G 8/B 9/g 9/q g/q", "abcdefhijkmnprtuvwxy0123456789ACDEFHJKLMNPQRTUVWXY", false},
+		{yid.CrockfordAlphabet, "2/Z 5/S 6/G 8/B", "0123456789ACDEFHJKMNPQRTVWXY", true},
+		{"0123456789abcdef", "", "0123456789abcdef", true},
+		{"OIl", "l/I", "Ol", false},
+	}
+	for _, tt := range tests {
+		r := yid.AnalyzeAlphabet(tt.alphabet)
+		var pairs []string
+		for _, p := range r.Confusables {
+			pairs = append(pairs, p.String())
+		}
+		if got := strings.Join(pairs, " "); got != tt.confusables {
+			t.Errorf("%s: expected confusables %q, got %q", tt.alphabet, tt.confusables, got)
+		}
+		if r.Safe != tt.safe || r.Length != len(tt.alphabet) || r.CaseInsensitive != tt.caseInsensitive {
+			t.Errorf("%s: unexpected report %+v", tt.alphabet, r)
+		}
+	}
+}
+
+// TestAnalyzeAlphabet_Invalid tests that the report carries New's error.
+func TestAnalyzeAlphabet_Invalid(t *testing.T) {
+	if err := yid.AnalyzeAlphabet("aa").Err; !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+	if err := yid.AnalyzeAlphabet(yid.DefaultAlphabet).Err; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestSafeAlphabet tests that safe alphabets have no confusables and keep order.
+func TestSafeAlphabet(t *testing.T) {
+	for _, alphabet := range []string{yid.DefaultAlphabet, yid.CrockfordAlphabet, "OoIl0123"} {
+		safe := yid.SafeAlphabet(alphabet)
+		if c := yid.AnalyzeAlphabet(safe).Confusables; len(c) != 0 {
+			t.Errorf("SafeAlphabet(%q) = %q still has confusables %v", alphabet, safe, c)
+		}
+		if yid.SafeAlphabet(safe) != safe {
+			t.Errorf("SafeAlphabet is not idempotent for %q", alphabet)
+		}
+	}
+	if got := yid.SafeAlphabet("OoIl0123"); got != "0123" {
+		t.Errorf("expected 0123, got %q", got)
+	}
+}
+
+// TestWithSafeAlphabet tests encoding with the safe default alphabet.
+func TestWithSafeAlphabet(t *testing.T) {
+	safe := yid.SafeAlphabet(yid.DefaultAlphabet)
+	enc := yid.New(yid.WithSafeAlphabet())
+	for _, n := range []int64{0, 1, 12345, 1 << 40, 1<<63 - 1} {
+		encoded, err := enc.Encode(n)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Trim(encoded, safe) != "" {
+			t.Errorf("Encode(%d) = %q uses confusable characters", n, encoded)
+		}
+		if got, err := enc.Decode(encoded); err != nil || got != n {
+			t.Errorf("Decode(%q) = %d, %v, want %d", encoded, got, err, n)
+		}
+	}
+	if _, err := enc.Decode("O"); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter without WithLookalikes, got %v", err)
+	}
+	if _, err := yid.New(yid.WithAlphabet("0O"), yid.WithSafeAlphabet()).Encode(1); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for a too short safe alphabet, got %v", err)
+	}
+}
+
+// TestWithLookalikes tests that excluded look-alikes decode as their counterparts.
+func TestWithLookalikes(t *testing.T) {
+	enc := yid.New(yid.WithSafeAlphabet(), yid.WithLookalikes())
+	encoded, _ := enc.Encode(1010)
+	tests := map[byte]string{'0': "Oo", '1': "lI", '2': "Zz", '5': "Ss", '6': "G", '8': "B", '9': "gq"}
+	for canonical, lookalikes := range tests {
+		want, _ := enc.Decode(encoded + string(canonical))
+		for i := 0; i < len(lookalikes); i++ {
+			got, err := enc.Decode(encoded + string(lookalikes[i]))
+			if err != nil || got != want {
+				t.Errorf("Decode with %q = %d, %v, want %d", lookalikes[i], got, err, want)
+			}
+			if !enc.Valid(encoded + string(lookalikes[i])) {
+				t.Errorf("Valid with %q = false, want true", lookalikes[i])
+			}
+		}
+	}
+}
+
+// TestWithLookalikes_Output tests that look-alike decoding leaves Encode unchanged.
+func TestWithLookalikes_Output(t *testing.T) {
+	for _, opts := range [][]yid.Option{nil, {yid.WithSecureKey("secret")}} {
+		plain := yid.New(opts...)
+		lenient := yid.New(append(opts, yid.WithLookalikes())...)
+		a, _ := plain.Encode(987654321)
+		b, _ := lenient.Encode(987654321)
+		if a != b {
+			t.Errorf("expected %q, got %q", a, b)
+		}
+		if !reflect.DeepEqual(plain.Pattern(), lenient.Pattern()) {
+			t.Error("expected WithLookalikes not to change the pattern")
+		}
+	}
+}
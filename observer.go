@@ -0,0 +1,146 @@
+package yid
+
+import (
+	"errors"
+	"time"
+)
+
+// Error classes returned by Classify.
+const (
+	ClassOK                = "ok"
+	ClassInvalidCharacter  = "invalid_character"
+	ClassNegativeNumber    = "negative_number"
+	ClassOverflow          = "overflow"
+	ClassInvalidLength     = "invalid_length"
+	ClassInvalidSignature  = "invalid_signature"
+	ClassInvalidPrefix     = "invalid_prefix"
+	ClassNotCanonical      = "not_canonical"
+	ClassExpired           = "expired"
+	ClassSignatureRequired = "signature_required"
+	ClassOther             = "other"
+)
+
+// Event describes one encode or decode call.
+// Encoder is the name set by WithName (empty if none), Duration is the time
+// spent in the call and Err is the error it returned, if any.
+type Event struct {
+	Encoder  string
+	Duration time.Duration
+	Err      error
+}
+
+// Class returns Classify(ev.Err).
+func (ev Event) Class() string {
+	return Classify(ev.Err)
+}
+
+// Observer receives an Event after every Encode, EncodeRaw, EncodeExpiring
+// (OnEncode) and Decode, DecodeExpiring (OnDecode) call.
+// Observers are called synchronously and must be safe for concurrent use.
+// See the expvarx and otelx packages for ready-made observers.
+type Observer interface {
+	OnEncode(ev Event)
+	OnDecode(ev Event)
+}
+
+// WithObserver adds an observer notified of every encode and decode.
+// Multiple observers are called in the order they were added.
+// Encoders without observers do not read the clock.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithName("user"), yid.WithObserver(expvarx.Publish("yid")))
+func WithObserver(o Observer) Option {
+	return func(c *config) {
+		if o != nil {
+			c.observers = append(c.observers, o)
+		}
+	}
+}
+
+// WithName names the encoder in observer events, for per-encoder breakdowns.
+// Registry.Derive names encoders after their registry name.
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// Classify maps an error returned by an Encoder to one of the Class
+// constants, for use as a metric label. A nil error is ClassOK.
+func Classify(err error) string {
+	switch {
+	case err == nil:
+		return ClassOK
+	case errors.Is(err, ErrInvalidCharacter):
+		return ClassInvalidCharacter
+	case errors.Is(err, ErrNegativeNumber):
+		return ClassNegativeNumber
+	case errors.Is(err, ErrOverflow):
+		return ClassOverflow
+	case errors.Is(err, ErrInvalidLength):
+		return ClassInvalidLength
+	case errors.Is(err, ErrInvalidSignature):
+		return ClassInvalidSignature
+	case errors.Is(err, ErrInvalidPrefix):
+		return ClassInvalidPrefix
+	case errors.Is(err, ErrNotCanonical):
+		return ClassNotCanonical
+	case errors.Is(err, ErrExpired):
+		return ClassExpired
+	case errors.Is(err, ErrSignatureRequired):
+		return ClassSignatureRequired
+	default:
+		return ClassOther
+	}
+}
+
+// observers fans out events to several observers.
+type observers []Observer
+
+func (os observers) OnEncode(ev Event) {
+	for _, o := range os {
+		o.OnEncode(ev)
+	}
+}
+
+func (os observers) OnDecode(ev Event) {
+	for _, o := range os {
+		o.OnDecode(ev)
+	}
+}
+
+// observer returns the configured observers as one Observer, or nil.
+func (c *config) observer() Observer {
+	switch len(c.observers) {
+	case 0:
+		return nil
+	case 1:
+		return c.observers[0]
+	default:
+		return observers(append([]Observer(nil), c.observers...))
+	}
+}
+
+// start returns the start time of an observed call, or the zero time if
+// the encoder has no observer.
+func (e *Encoder) start() time.Time {
+	if e.observer == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// observeEncode notifies the observer of an encode that began at start.
+func (e *Encoder) observeEncode(start time.Time, err error) {
+	if e.observer != nil {
+		e.observer.OnEncode(Event{Encoder: e.name, Duration: time.Since(start), Err: err})
+	}
+}
+
+// observeDecode notifies the observer of a decode that began at start.
+func (e *Encoder) observeDecode(start time.Time, err error) {
+	if e.observer != nil {
+		e.observer.OnDecode(Event{Encoder: e.name, Duration: time.Since(start), Err: err})
+	}
+}
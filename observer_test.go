@@ -0,0 +1,111 @@
+package yid_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// recorder is an Observer that records events.
+type recorder struct {
+	mu      sync.Mutex
+	encodes []yid.Event
+	decodes []yid.Event
+}
+
+func (r *recorder) OnEncode(ev yid.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.encodes = append(r.encodes, ev)
+}
+
+func (r *recorder) OnDecode(ev yid.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decodes = append(r.decodes, ev)
+}
+
+// TestObserver_Events tests that each public call is observed exactly once.
+func TestObserver_Events(t *testing.T) {
+	rec := &recorder{}
+	enc := yid.New(yid.WithName("user"), yid.WithObserver(rec), yid.WithSignature([]byte("key"), 6))
+
+	enc.Encode(12345)
+	encoded, _ := enc.EncodeRaw(12345)
+	enc.Decode(encoded)
+	enc.Decode("dn-aaaaaa")
+	token, _ := enc.EncodeExpiring(1, time.Unix(1000, 0))
+	enc.DecodeExpiring(token, time.Unix(2000, 0))
+
+	if len(rec.encodes) != 3 {
+		t.Fatalf("expected 3 encode events, got %d", len(rec.encodes))
+	}
+	if len(rec.decodes) != 3 {
+		t.Fatalf("expected 3 decode events, got %d", len(rec.decodes))
+	}
+	for _, ev := range append(rec.encodes, rec.decodes...) {
+		if ev.Encoder != "user" {
+			t.Errorf("expected encoder name user, got %q", ev.Encoder)
+		}
+	}
+	classes := []string{rec.decodes[0].Class(), rec.decodes[1].Class(), rec.decodes[2].Class()}
+	expected := []string{yid.ClassOK, yid.ClassInvalidCharacter, yid.ClassExpired}
+	for i := range classes {
+		if classes[i] != expected[i] {
+			t.Errorf("decode %d: expected class %s, got %s", i, expected[i], classes[i])
+		}
+	}
+}
+
+// TestObserver_Multiple tests that all observers are notified in order.
+func TestObserver_Multiple(t *testing.T) {
+	a, b := &recorder{}, &recorder{}
+	enc := yid.New(yid.WithObserver(a), yid.WithObserver(nil), yid.WithObserver(b))
+	enc.Decode("dnh")
+	if len(a.decodes) != 1 || len(b.decodes) != 1 {
+		t.Errorf("expected both observers to be notified, got %d and %d", len(a.decodes), len(b.decodes))
+	}
+}
+
+// TestRegistry_DeriveNamesEncoder tests that derived encoders are named for observers.
+func TestRegistry_DeriveNamesEncoder(t *testing.T) {
+	rec := &recorder{}
+	r := yid.NewRegistry([]byte("master-secret"))
+	user, _ := r.Derive("user", yid.WithObserver(rec))
+	org, _ := r.Derive("org", yid.WithObserver(rec), yid.WithName("organization"))
+	user.Encode(1)
+	org.Encode(1)
+	if rec.encodes[0].Encoder != "user" || rec.encodes[1].Encoder != "organization" {
+		t.Errorf("unexpected names: %q, %q", rec.encodes[0].Encoder, rec.encodes[1].Encoder)
+	}
+}
+
+// TestClassify tests the mapping from errors to classes.
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{nil, yid.ClassOK},
+		{yid.ErrInvalidCharacter, yid.ClassInvalidCharacter},
+		{yid.ErrNegativeNumber, yid.ClassNegativeNumber},
+		{yid.ErrOverflow, yid.ClassOverflow},
+		{yid.ErrInvalidLength, yid.ClassInvalidLength},
+		{yid.ErrInvalidSignature, yid.ClassInvalidSignature},
+		{yid.ErrInvalidPrefix, yid.ClassInvalidPrefix},
+		{yid.ErrNotCanonical, yid.ClassNotCanonical},
+		{&yid.ExpiredError{}, yid.ClassExpired},
+		{yid.ErrSignatureRequired, yid.ClassSignatureRequired},
+		{fmt.Errorf("wrapped: %w", yid.ErrOverflow), yid.ClassOverflow},
+		{errors.New("boom"), yid.ClassOther},
+	}
+	for _, tt := range tests {
+		if got := yid.Classify(tt.err); got != tt.expected {
+			t.Errorf("Classify(%v) = %s, want %s", tt.err, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package otelx reports yid encode/decode metrics through an
+// OpenTelemetry-style meter without depending on the OpenTelemetry SDK.
+//
+// Meter, Int64Counter and Float64Histogram mirror the method sets of the
+// OpenTelemetry metric API closely enough that a few lines of glue code
+// adapt a real go.opentelemetry.io/otel/metric.Meter, while tests can use an
+// in-memory implementation. Nothing in this package touches the network.
+//
+// Two instruments are recorded, both with the attributes
+// "yid.encoder" (the name from yid.WithName), "yid.operation" ("encode" or
+// "decode") and "yid.result" (yid.Classify of the error):
+//
+//   - CounterName, an Int64Counter of calls;
+//   - DurationName, a Float64Histogram of call durations in seconds.
+//
+// Example:
+//
+//	obs, err := otelx.New(meterAdapter{otel.Meter("yid")})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	enc := yid.New(yid.WithName("user"), yid.WithObserver(obs))
+package otelx
+
+import (
+	"context"
+	"fmt"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// Instrument names.
+const (
+	CounterName  = "yid.operations"
+	DurationName = "yid.duration"
+)
+
+// Attribute keys.
+const (
+	EncoderKey   = "yid.encoder"
+	OperationKey = "yid.operation"
+	ResultKey    = "yid.result"
+)
+
+// Attribute is a string key-value pair attached to a measurement.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Int64Counter is a monotonic counter.
+type Int64Counter interface {
+	Add(ctx context.Context, incr int64, attrs ...Attribute)
+}
+
+// Float64Histogram records a distribution of values.
+type Float64Histogram interface {
+	Record(ctx context.Context, value float64, attrs ...Attribute)
+}
+
+// Meter creates instruments.
+type Meter interface {
+	Int64Counter(name, description, unit string) (Int64Counter, error)
+	Float64Histogram(name, description, unit string) (Float64Histogram, error)
+}
+
+// Observer is a yid.Observer that records events with a Meter.
+// It is safe for concurrent use if the instruments are.
+type Observer struct {
+	calls    Int64Counter
+	duration Float64Histogram
+}
+
+// New creates the instruments on m and returns an Observer using them.
+func New(m Meter) (*Observer, error) {
+	calls, err := m.Int64Counter(CounterName, "Number of yid encode and decode calls.", "{call}")
+	if err != nil {
+		return nil, fmt.Errorf("otelx: create %s: %w", CounterName, err)
+	}
+	duration, err := m.Float64Histogram(DurationName, "Duration of yid encode and decode calls.", "s")
+	if err != nil {
+		return nil, fmt.Errorf("otelx: create %s: %w", DurationName, err)
+	}
+	return &Observer{calls: calls, duration: duration}, nil
+}
+
+// OnEncode implements yid.Observer.
+func (o *Observer) OnEncode(ev yid.Event) {
+	o.record("encode", ev)
+}
+
+// OnDecode implements yid.Observer.
+func (o *Observer) OnDecode(ev yid.Event) {
+	o.record("decode", ev)
+}
+
+// record adds ev to both instruments.
+func (o *Observer) record(op string, ev yid.Event) {
+	attrs := []Attribute{
+		{Key: EncoderKey, Value: ev.Encoder},
+		{Key: OperationKey, Value: op},
+		{Key: ResultKey, Value: ev.Class()},
+	}
+	ctx := context.Background()
+	o.calls.Add(ctx, 1, attrs...)
+	o.duration.Record(ctx, ev.Duration.Seconds(), attrs...)
+}
@@ -0,0 +1,103 @@
+package otelx_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/otelx"
+)
+
+// memMeter is an in-memory Meter keyed by instrument name and attributes.
+type memMeter struct {
+	mu       sync.Mutex
+	counts   map[string]int64
+	records  map[string]int
+	failWith error
+}
+
+func newMemMeter() *memMeter {
+	return &memMeter{counts: make(map[string]int64), records: make(map[string]int)}
+}
+
+func key(name string, attrs []otelx.Attribute) string {
+	k := name
+	for _, a := range attrs {
+		k += " " + a.Key + "=" + a.Value
+	}
+	return k
+}
+
+type memCounter struct {
+	m    *memMeter
+	name string
+}
+
+func (c memCounter) Add(_ context.Context, incr int64, attrs ...otelx.Attribute) {
+	c.m.mu.Lock()
+	defer c.m.mu.Unlock()
+	c.m.counts[key(c.name, attrs)] += incr
+}
+
+type memHistogram struct {
+	m    *memMeter
+	name string
+}
+
+func (h memHistogram) Record(_ context.Context, _ float64, attrs ...otelx.Attribute) {
+	h.m.mu.Lock()
+	defer h.m.mu.Unlock()
+	h.m.records[key(h.name, attrs)]++
+}
+
+func (m *memMeter) Int64Counter(name, _, _ string) (otelx.Int64Counter, error) {
+	return memCounter{m, name}, nil
+}
+
+func (m *memMeter) Float64Histogram(name, _, _ string) (otelx.Float64Histogram, error) {
+	if m.failWith != nil {
+		return nil, m.failWith
+	}
+	return memHistogram{m, name}, nil
+}
+
+// TestObserver_Records tests that calls are counted with their attributes.
+func TestObserver_Records(t *testing.T) {
+	meter := newMemMeter()
+	obs, err := otelx.New(meter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	enc := yid.New(yid.WithName("user"), yid.WithObserver(obs))
+	enc.Encode(12345)
+	enc.Decode("dnh")
+	enc.Decode("dn-")
+	enc.EncodeRaw(-1)
+
+	tests := map[string]int64{
+		"yid.operations yid.encoder=user yid.operation=encode yid.result=ok":                1,
+		"yid.operations yid.encoder=user yid.operation=decode yid.result=ok":                1,
+		"yid.operations yid.encoder=user yid.operation=decode yid.result=invalid_character": 1,
+		"yid.operations yid.encoder=user yid.operation=encode yid.result=negative_number":   1,
+	}
+	for k, expected := range tests {
+		if got := meter.counts[k]; got != expected {
+			t.Errorf("%s: expected %d, got %d", k, expected, got)
+		}
+	}
+	if got := meter.records["yid.duration yid.encoder=user yid.operation=decode yid.result=ok"]; got != 1 {
+		t.Errorf("expected 1 duration record, got %d", got)
+	}
+}
+
+// TestNew_Error tests that instrument errors are returned.
+func TestNew_Error(t *testing.T) {
+	boom := errors.New("boom")
+	meter := newMemMeter()
+	meter.failWith = boom
+	if _, err := otelx.New(meter); !errors.Is(err, boom) {
+		t.Errorf("expected boom, got %v", err)
+	}
+}
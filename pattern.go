@@ -0,0 +1,355 @@
+package yid
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema is a JSON Schema (and OpenAPI) description of the strings Encode returns.
+type Schema struct {
+	Type        string `json:"type"`
+	Pattern     string `json:"pattern"`
+	MinLength   int    `json:"minLength"`
+	MaxLength   int    `json:"maxLength"`
+	Description string `json:"description,omitempty"`
+}
+
+// Pattern returns an anchored regular expression that matches exactly the
+// strings Encode can return for some non-negative number: the prefix, the
+// digits of every encodable value in the encoder's dictionary (without
+// leading zeros, or padded to SortableLength in sortable mode, and never
+// below the padUp minimum or above math.MaxInt64), the case transformation,
+// the signature characters and the group separators of WithGrouping.
+// Signatures are matched by length and alphabet only; Decode verifies them.
+// EncodeExpiring tokens are not matched.
+//
+// The expression only uses syntax shared by Go's regexp package and
+// ECMA-262, so it can be embedded in JSON Schema and OpenAPI documents.
+//
+// Example:
+//
+//	yid.New().Pattern() // -> "^(?:[a-zA-Z0-9]|[b-zA-Z0-9][a-zA-Z0-9]|...)$"
+func (e *Encoder) Pattern() string {
+	var b strings.Builder
+	b.WriteString("^")
+	b.WriteString(regexp.QuoteMeta(e.prefix))
+
+	alts := e.valueSeqs()
+	if e.groupSize > 0 {
+		// Groups span the signature, so each alternative carries its own.
+		for i := range alts {
+			alts[i] = append(alts[i], e.signatureSpans()...)
+		}
+	}
+	if len(alts) > 1 {
+		b.WriteString("(?:")
+	}
+	for i, s := range alts {
+		if i > 0 {
+			b.WriteString("|")
+		}
+		e.writeGroups(&b, s)
+	}
+	if len(alts) > 1 {
+		b.WriteString(")")
+	}
+
+	if e.groupSize == 0 {
+		e.writeSeq(&b, e.signatureSpans())
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// JSONSchema returns a string schema with Pattern and the minimum and maximum
+// lengths of Encode's output.
+//
+// Example:
+//
+//	data, _ := json.Marshal(enc.JSONSchema())
+//	// -> {"type":"string","pattern":"^...$","minLength":1,"maxLength":11}
+func (e *Encoder) JSONSchema() Schema {
+	minLen, maxLen := e.lengths()
+	return Schema{
+		Type:      "string",
+		Pattern:   e.Pattern(),
+		MinLength: e.groupedLen(minLen),
+		MaxLength: e.groupedLen(maxLen),
+	}
+}
+
+// groupedLen returns the length of an output of n characters, including
+// the prefix, after grouping.
+func (e *Encoder) groupedLen(n int) int {
+	body := n - len(e.prefix)
+	if e.groupSize == 0 || body == 0 {
+		return n
+	}
+	return n + (body-1)/e.groupSize*len(e.groupSep)
+}
+
+// signatureSpans returns the spans matching the signature, if any.
+func (e *Encoder) signatureSpans() seq {
+	if !e.signed() {
+		return nil
+	}
+	return seq{{0, len(e.dictionary) - 1, e.signChars}}
+}
+
+// writeGroups writes s like writeSeq, with the group separator after every
+// groupSize digits.
+func (e *Encoder) writeGroups(b *strings.Builder, s seq) {
+	if e.groupSize == 0 {
+		e.writeSeq(b, s)
+		return
+	}
+	var group seq
+	n, groups := 0, 0
+	flush := func() {
+		if groups > 0 {
+			b.WriteString(regexp.QuoteMeta(e.groupSep))
+		}
+		e.writeSeq(b, group)
+		group, n = nil, 0
+		groups++
+	}
+	for _, sp := range s {
+		for sp.count > 0 {
+			take := min(sp.count, e.groupSize-n)
+			group = append(group, span{sp.lo, sp.hi, take})
+			sp.count -= take
+			n += take
+			if n == e.groupSize {
+				flush()
+			}
+		}
+	}
+	if n > 0 {
+		flush()
+	}
+}
+
+// span is a run of count digits, each with a value in [lo, hi].
+type span struct {
+	lo, hi, count int
+}
+
+// seq is a sequence of spans describing a set of digit strings.
+type seq []span
+
+// valueBounds returns the smallest and largest value e.table.Encode is given.
+func (e *Encoder) valueBounds() (lo, hi int64) {
+	if e.padUp > 1 {
+		lo = 1
+		for i := 1; i < e.padUp; i++ {
+			lo *= int64(len(e.dictionary))
+		}
+	}
+	return lo, math.MaxInt64
+}
+
+// valueSeqs returns sequences whose union is the set of digit strings that
+// encodeNumber can return.
+func (e *Encoder) valueSeqs() []seq {
+	base := len(e.dictionary)
+	lo, hi := e.valueBounds()
+	if e.sortable {
+		return digitRange(digits(lo, base, SortableLength), digits(hi, base, SortableLength), base-1)
+	}
+
+	var out []seq
+	minWidth, maxWidth := len(digits(lo, base, 0)), len(digits(hi, base, 0))
+	for width := minWidth; width <= maxWidth; width++ {
+		// Smallest and largest values written with exactly width digits.
+		from, to := lo, hi
+		if width > minWidth {
+			from = smallest(width, base)
+		}
+		if width < maxWidth {
+			to = smallest(width+1, base) - 1
+		}
+		out = append(out, digitRange(digits(from, base, width), digits(to, base, width), base-1)...)
+	}
+	return out
+}
+
+// lengths returns the minimum and maximum length of Encode's output before grouping.
+func (e *Encoder) lengths() (minLen, maxLen int) {
+	base := len(e.dictionary)
+	lo, hi := e.valueBounds()
+	minLen, maxLen = len(digits(lo, base, 0)), len(digits(hi, base, 0))
+	if e.sortable {
+		minLen, maxLen = SortableLength, SortableLength
+	}
+	extra := len(e.prefix)
+	if e.signed() {
+		extra += e.signChars
+	}
+	return minLen + extra, maxLen + extra
+}
+
+// smallest returns the smallest value written with width digits (width > 1).
+func smallest(width, base int) int64 {
+	v := int64(1)
+	for i := 1; i < width; i++ {
+		v *= int64(base)
+	}
+	return v
+}
+
+// digits returns the base digits of v, most significant first, left-padded
+// with zeros to width. Zero has one digit.
+func digits(v int64, base, width int) []int {
+	var out []int
+	for {
+		out = append(out, int(v%int64(base)))
+		v /= int64(base)
+		if v == 0 {
+			break
+		}
+	}
+	for len(out) < width {
+		out = append(out, 0)
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// digitRange returns sequences matching exactly the digit strings of the
+// same width as lo and hi whose value lies in [lo, hi]. max is the largest digit.
+func digitRange(lo, hi []int, max int) []seq {
+	n := len(lo)
+	if n == 0 {
+		return []seq{nil}
+	}
+	if lo[0] == hi[0] {
+		return prepend(span{lo[0], lo[0], 1}, digitRange(lo[1:], hi[1:], max))
+	}
+
+	var out []seq
+	first, last := lo[0], hi[0]
+	lowTail := !allDigits(lo[1:], 0)
+	highTail := !allDigits(hi[1:], max)
+	if lowTail {
+		out = append(out, prepend(span{lo[0], lo[0], 1}, digitRange(lo[1:], repeat(max, n-1), max))...)
+		first++
+	}
+	if highTail {
+		last--
+	}
+	if first <= last {
+		s := seq{{first, last, 1}}
+		if n > 1 {
+			s = append(s, span{0, max, n - 1})
+		}
+		out = append(out, s)
+	}
+	if highTail {
+		out = append(out, prepend(span{hi[0], hi[0], 1}, digitRange(repeat(0, n-1), hi[1:], max))...)
+	}
+	return out
+}
+
+// prepend returns seqs with sp added in front of each.
+func prepend(sp span, seqs []seq) []seq {
+	out := make([]seq, len(seqs))
+	for i, s := range seqs {
+		out[i] = append(seq{sp}, s...)
+	}
+	return out
+}
+
+// allDigits reports whether every digit in ds equals d.
+func allDigits(ds []int, d int) bool {
+	for _, x := range ds {
+		if x != d {
+			return false
+		}
+	}
+	return true
+}
+
+// repeat returns n copies of d.
+func repeat(d, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = d
+	}
+	return out
+}
+
+// writeSeq writes s as character classes, merging adjacent identical classes
+// into a single quantified class.
+func (e *Encoder) writeSeq(b *strings.Builder, s seq) {
+	var prev string
+	count := 0
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		b.WriteString(prev)
+		if count > 1 {
+			b.WriteString("{" + strconv.Itoa(count) + "}")
+		}
+	}
+	for _, sp := range s {
+		class := e.class(sp.lo, sp.hi)
+		if class != prev {
+			flush()
+			prev, count = class, 0
+		}
+		count += sp.count
+	}
+	flush()
+}
+
+// class returns a regular expression matching the transformed dictionary
+// characters for digits lo through hi.
+func (e *Encoder) class(lo, hi int) string {
+	chars := []byte(applyCaseTransform(e.dictionary[lo:hi+1], e.transform))
+	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(chars); {
+		if i > 0 && chars[i] == chars[i-1] {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < len(chars) && (chars[j+1] == chars[j]+1 || chars[j+1] == chars[j]) {
+			j++
+		}
+		switch {
+		case chars[j] == chars[i]:
+			b.WriteString(classChar(chars[i]))
+			n++
+		case chars[j] == chars[i]+1:
+			b.WriteString(classChar(chars[i]) + classChar(chars[j]))
+			n += 2
+		default:
+			b.WriteString(classChar(chars[i]) + "-" + classChar(chars[j]))
+			n += 3
+		}
+		i = j + 1
+	}
+	if n == 1 {
+		return regexp.QuoteMeta(string(chars[0]))
+	}
+	return "[" + b.String() + "]"
+}
+
+// classChar returns c escaped for use inside a character class. QuoteMeta
+// does not escape '-', which would otherwise form a range.
+func classChar(c byte) string {
+	switch c {
+	case '\\', ']', '[', '^', '-':
+		return `\` + string(c)
+	}
+	return regexp.QuoteMeta(string(c))
+}
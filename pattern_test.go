@@ -0,0 +1,242 @@
+package yid_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// patternEncoders covers the options that change Encode's output format.
+var patternEncoders = map[string][]yid.Option{
+	"default":        nil,
+	"padUp 1":        {yid.WithPadUp(1)},
+	"padUp 3":        {yid.WithPadUp(3)},
+	"padUp max":      {yid.WithPadUp(yid.MaxPadUp)},
+	"secure":         {yid.WithSecureKey("secret")},
+	"sortable":       {yid.WithSortable()},
+	"sortable padUp": {yid.WithSortable(), yid.WithPadUp(4)},
+	"prefix":         {yid.WithPrefix("usr.")},
+}
+
+// canonical reports whether s is exactly what enc.EncodeRaw returns for some number.
+func canonical(enc *yid.Encoder, s string) bool {
+	n, err := enc.Decode(s)
+	if err != nil || n < 0 {
+		return false
+	}
+	again, err := enc.EncodeRaw(n)
+	return err == nil && again == s
+}
+
+// candidates returns strings near the boundaries of enc's output: encodings
+// of boundary numbers with single characters replaced, dropped or added,
+// plus random strings.
+func candidates(enc *yid.Encoder, rng *rand.Rand) []string {
+	var seeds []string
+	for _, n := range []int64{0, 1, 61, 62, 3843, 3844, 238327, math.MaxInt64 / 62, math.MaxInt64 - 1, math.MaxInt64} {
+		if s, err := enc.EncodeRaw(n); err == nil {
+			seeds = append(seeds, s)
+		}
+	}
+	out := append([]string{"", "a", "-", "0"}, seeds...)
+	for _, s := range seeds {
+		for i := 0; i < len(s); i++ {
+			for _, c := range []byte{'a', 'b', 'z', '0', '9', 'A', 'Z', s[i] + 1, s[i] - 1} {
+				out = append(out, s[:i]+string(c)+s[i+1:])
+			}
+			out = append(out, s[:i]+s[i+1:])
+		}
+		out = append(out, s+"a", "a"+s, "b"+s)
+	}
+	for i := 0; i < 2000; i++ {
+		b := make([]byte, rng.Intn(14))
+		for j := range b {
+			b[j] = dictionary[rng.Intn(len(dictionary))]
+		}
+		out = append(out, string(b))
+	}
+	return out
+}
+
+// TestPattern_Exact tests that the pattern matches a string iff it is a canonical encoding.
+func TestPattern_Exact(t *testing.T) {
+	for name, opts := range patternEncoders {
+		t.Run(name, func(t *testing.T) {
+			enc := yid.New(opts...)
+			re := regexp.MustCompile(enc.Pattern())
+			rng := rand.New(rand.NewSource(1))
+			for _, s := range candidates(enc, rng) {
+				if got, want := re.MatchString(s), canonical(enc, s); got != want {
+					t.Errorf("pattern match of %q = %t, want %t", s, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestPattern_Signed tests that signatures are matched by shape: the pattern
+// accepts any signature characters, Decode then verifies them.
+func TestPattern_Signed(t *testing.T) {
+	unsigned := yid.New(yid.WithSecureKey("secret"))
+	enc := yid.New(yid.WithSecureKey("secret"), yid.WithSignature([]byte("hmac-secret"), 4))
+	re := regexp.MustCompile(enc.Pattern())
+	rng := rand.New(rand.NewSource(3))
+	for _, s := range candidates(unsigned, rng) {
+		for _, sig := range []string{"", "abc", "abcd", "ab-d", "abcde"} {
+			full := s + sig
+			cut := len(full) - 4
+			want := cut > 0 && strings.Trim(full[cut:], dictionary) == "" && canonical(unsigned, full[:cut])
+			if got := re.MatchString(full); got != want {
+				t.Errorf("pattern match of %q = %t, want %t", full, got, want)
+			}
+		}
+	}
+}
+
+// TestPattern_MatchesEncode tests that every encoding matches, including transformed ones.
+func TestPattern_MatchesEncode(t *testing.T) {
+	opts := map[string][]yid.Option{
+		"signed": {yid.WithSignature([]byte("hmac-secret"), 4), yid.WithSecureKey("secret")},
+		"upper":  {yid.WithTransform(yid.TransformUpper), yid.WithSecureKey("secret")},
+		"lower":  {yid.WithTransform(yid.TransformLower), yid.WithPadUp(5), yid.WithPrefix("x_")},
+	}
+	for name, o := range patternEncoders {
+		opts[name] = o
+	}
+	rng := rand.New(rand.NewSource(2))
+	for name, o := range opts {
+		t.Run(name, func(t *testing.T) {
+			enc := yid.New(o...)
+			schema := enc.JSONSchema()
+			re := regexp.MustCompile(schema.Pattern)
+			for i := 0; i < 2000; i++ {
+				n := rng.Int63() >> uint(rng.Intn(63))
+				s, err := enc.Encode(n)
+				if err != nil {
+					continue
+				}
+				if !re.MatchString(s) {
+					t.Fatalf("Encode(%d) = %q does not match %s", n, s, schema.Pattern)
+				}
+				if len(s) < schema.MinLength || len(s) > schema.MaxLength {
+					t.Fatalf("Encode(%d) = %q outside length [%d, %d]", n, s, schema.MinLength, schema.MaxLength)
+				}
+			}
+		})
+	}
+}
+
+// TestPattern_Transformed tests that a transformed pattern only contains transformed characters.
+func TestPattern_Transformed(t *testing.T) {
+	p := yid.New(yid.WithTransform(yid.TransformUpper)).Pattern()
+	if strings.ContainsAny(p, "abcdefghijklmnopqrstuvwxyz") {
+		t.Errorf("expected no lower-case characters, got %s", p)
+	}
+	if regexp.MustCompile(p).MatchString("dnh") {
+		t.Error("expected lower-case input not to match")
+	}
+}
+
+// TestJSONSchema_Lengths tests the length bounds.
+func TestJSONSchema_Lengths(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []yid.Option
+		min, max int
+	}{
+		{"default", nil, 1, 11},
+		{"padUp", []yid.Option{yid.WithPadUp(4)}, 4, 11},
+		{"sortable", []yid.Option{yid.WithSortable()}, 11, 11},
+		{"prefix and signature", []yid.Option{yid.WithPrefix("usr_"), yid.WithSignature([]byte("k"), 6)}, 11, 21},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := yid.New(tt.opts...).JSONSchema()
+			if s.MinLength != tt.min || s.MaxLength != tt.max {
+				t.Errorf("expected [%d, %d], got [%d, %d]", tt.min, tt.max, s.MinLength, s.MaxLength)
+			}
+		})
+	}
+}
+
+// TestJSONSchema_Marshal tests the JSON form of the schema.
+func TestJSONSchema_Marshal(t *testing.T) {
+	data, err := json.Marshal(yid.New(yid.WithSortable()).JSONSchema())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"type":"string","pattern":"^(?:[0-9][0-9A-Za-z]{10}|A[0-9A-Za-y][0-9A-Za-z]{9}|Az[0-9A-K][0-9A-Za-z]{8}|AzL[0-7][0-9A-Za-z]{7}|AzL8[0-9A-Za-m][0-9A-Za-z]{6}|AzL8n0[0-9A-X][0-9A-Za-z]{4}|AzL8n0Y[0-4][0-9A-Za-z]{3}|AzL8n0Y5[0-7][0-9A-Za-z]{2}|AzL8n0Y58[0-9A-Za-l][0-9A-Za-z]|AzL8n0Y58m[0-7])$","minLength":11,"maxLength":11}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+// TestPattern_PunctuationAlphabets tests that characters with a meaning in
+// character classes are escaped, so the pattern agrees with Valid.
+func TestPattern_PunctuationAlphabets(t *testing.T) {
+	alphabets := []string{
+		"+-0",
+		"-]^\\",
+		"!#$%&*+-./:;<=>?@^_~",
+		"[]^-\\0123456789",
+	}
+	cases := []struct {
+		opts   []yid.Option
+		sigLen int
+	}{
+		{nil, 0},
+		{[]yid.Option{yid.WithPadUp(3)}, 0},
+		{[]yid.Option{yid.WithSignature([]byte("k"), 2)}, 2},
+	}
+	var printable []byte
+	for c := byte('!'); c <= '~'; c++ {
+		printable = append(printable, c)
+	}
+	rng := rand.New(rand.NewSource(7))
+	for _, alphabet := range alphabets {
+		for _, tc := range cases {
+			enc := yid.New(append([]yid.Option{yid.WithAlphabet(alphabet)}, tc.opts...)...)
+			re, err := regexp.Compile(enc.Pattern())
+			if err != nil {
+				t.Fatalf("%q: invalid pattern %s: %v", alphabet, enc.Pattern(), err)
+			}
+			// Signatures are matched by length and alphabet only, so the
+			// payload is checked with the unsigned encoder.
+			payload := yid.New(yid.WithAlphabet(alphabet))
+			if tc.sigLen == 0 {
+				payload = enc
+			}
+			var inputs []string
+			for n := int64(0); n < 300; n++ {
+				s, _ := enc.EncodeRaw(n)
+				inputs = append(inputs, s)
+			}
+			for i := 0; i < 3000; i++ {
+				b := make([]byte, 1+rng.Intn(8))
+				for j := range b {
+					if rng.Intn(2) == 0 {
+						b[j] = alphabet[rng.Intn(len(alphabet))]
+					} else {
+						b[j] = printable[rng.Intn(len(printable))]
+					}
+				}
+				inputs = append(inputs, string(b))
+			}
+			for _, s := range inputs {
+				cut := len(s) - tc.sigLen
+				want := cut > 0 && strings.Trim(s[cut:], alphabet) == "" && payload.Valid(s[:cut])
+				if got := re.MatchString(s); got != want {
+					t.Errorf("%q: pattern match of %q = %t, want %t (pattern %s)", alphabet, s, got, want, enc.Pattern())
+				}
+			}
+		}
+	}
+	if regexp.MustCompile(yid.New(yid.WithAlphabet("+-a")).Pattern()).MatchString(",") {
+		t.Error("expected \",\" not to match the pattern of alphabet +-a")
+	}
+}
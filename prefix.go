@@ -0,0 +1,39 @@
+package yid
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidPrefix is returned when decoding input that does not start with
+// the encoder's prefix.
+var ErrInvalidPrefix = errors.New("yid: missing or wrong prefix")
+
+// WithPrefix sets a fixed prefix, such as "usr_", that Encode, EncodeRaw and
+// EncodeExpiring prepend and Decode and DecodeExpiring require. Prefixes make
+// IDs of different entity types distinguishable at a glance. The prefix is
+// not affected by WithTransform.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithPrefix("usr_"))
+//	enc.Encode(12345)       // -> "usr_dnh"
+//	enc.Decode("usr_dnh")   // -> 12345
+//	enc.Decode("dnh")       // -> ErrInvalidPrefix
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// trimPrefix removes the encoder's prefix from s.
+func (e *Encoder) trimPrefix(s string) (string, error) {
+	if e.prefix == "" {
+		return s, nil
+	}
+	rest, ok := strings.CutPrefix(s, e.prefix)
+	if !ok {
+		return "", ErrInvalidPrefix
+	}
+	return rest, nil
+}
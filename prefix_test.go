@@ -0,0 +1,68 @@
+package yid_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestPrefix_Roundtrip tests that the prefix is added and required.
+func TestPrefix_Roundtrip(t *testing.T) {
+	enc := yid.New(yid.WithPrefix("usr_"))
+	encoded, err := enc.Encode(12345)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoded != "usr_dnh" {
+		t.Errorf("expected usr_dnh, got %q", encoded)
+	}
+	raw, _ := enc.EncodeRaw(12345)
+	if raw != "usr_dnh" {
+		t.Errorf("expected raw usr_dnh, got %q", raw)
+	}
+	n, err := enc.Decode("usr_dnh")
+	if err != nil || n != 12345 {
+		t.Errorf("expected 12345, got %d, %v", n, err)
+	}
+}
+
+// TestPrefix_Invalid tests that inputs without the prefix are rejected.
+func TestPrefix_Invalid(t *testing.T) {
+	enc := yid.New(yid.WithPrefix("usr_"))
+	for _, input := range []string{"dnh", "org_dnh", "usr", ""} {
+		if _, err := enc.Decode(input); !errors.Is(err, yid.ErrInvalidPrefix) {
+			t.Errorf("Decode(%q): expected ErrInvalidPrefix, got %v", input, err)
+		}
+	}
+}
+
+// TestPrefix_NotTransformed tests that case transformations leave the prefix alone.
+func TestPrefix_NotTransformed(t *testing.T) {
+	encoded, _ := yid.ToAlphanumeric(12345, yid.WithPrefix("usr_"), yid.WithTransform(yid.TransformUpper))
+	if encoded != "usr_DNH" {
+		t.Errorf("expected usr_DNH, got %q", encoded)
+	}
+}
+
+// TestPrefix_WithSignatureAndExpiry tests prefixes on signed and expiring tokens.
+func TestPrefix_WithSignatureAndExpiry(t *testing.T) {
+	enc := yid.New(yid.WithPrefix("inv_"), yid.WithSignature([]byte("hmac-secret"), 6))
+	signed, _ := enc.Encode(7)
+	if n, err := enc.Decode(signed); err != nil || n != 7 {
+		t.Errorf("expected 7, got %d, %v", n, err)
+	}
+
+	expires := time.Unix(2000, 0)
+	token, err := enc.EncodeExpiring(7, expires)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, err := enc.DecodeExpiring(token, time.Unix(1000, 0)); err != nil || n != 7 {
+		t.Errorf("expected 7, got %d, %v", n, err)
+	}
+	if _, err := enc.DecodeExpiring(token[4:], time.Unix(1000, 0)); !errors.Is(err, yid.ErrInvalidPrefix) {
+		t.Errorf("expected ErrInvalidPrefix, got %v", err)
+	}
+}
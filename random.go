@@ -0,0 +1,117 @@
+package yid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// WithRandSource sets the source of randomness for Encoder.Random.
+// Defaults to crypto/rand.Reader; pass a deterministic reader in tests.
+func WithRandSource(r io.Reader) Option {
+	return func(c *config) {
+		c.randSource = r
+	}
+}
+
+// Random returns a random ID whose digits are length dictionary characters,
+// suitable for invite codes and other unguessable tokens. It draws a number
+// uniformly among those whose encoding has exactly length digits and
+// returns it as EncodeRaw would, with the prefix and signature, so that
+// Valid accepts it and Decode returns the number. Only the random bytes the
+// draw needs are read; values outside the range are rejected and redrawn to
+// avoid bias. Returns ErrInvalidLength if no number has length digits (in
+// sortable mode, if length is not SortableLength).
+//
+// Example:
+//
+//	enc := yid.New()
+//	code, _ := enc.Random(8) // -> e.g. "x3KfQ9aZ"
+func (e *Encoder) Random(length int) (string, error) {
+	if e.err != nil {
+		return "", e.err
+	}
+	lo, hi, ok := e.randomRange(length)
+	if !ok {
+		return "", ErrInvalidLength
+	}
+
+	source := e.randSource
+	if source == nil {
+		source = rand.Reader
+	}
+	v, err := randomUint(source, uint64(hi-lo))
+	if err != nil {
+		return "", err
+	}
+	offset, _ := e.valueBounds()
+	raw, err := e.encodeRaw(lo + int64(v) - offset)
+	if err != nil {
+		return "", err
+	}
+	return e.prefix + raw, nil
+}
+
+// randomRange returns the smallest and largest digit value written with
+// length digits that encodeNumber can produce.
+func (e *Encoder) randomRange(length int) (lo, hi int64, ok bool) {
+	base := len(e.dictionary)
+	minValue, maxValue := e.valueBounds()
+	if e.sortable {
+		return minValue, maxValue, length == SortableLength
+	}
+	maxWidth := len(digits(maxValue, base, 0))
+	if length <= 0 || length > maxWidth {
+		return 0, 0, false
+	}
+	lo, hi = 0, maxValue
+	if length > 1 {
+		lo = smallest(length, base)
+	}
+	if length < maxWidth {
+		hi = smallest(length+1, base) - 1
+	}
+	lo = max(lo, minValue)
+	return lo, hi, lo <= hi
+}
+
+// randomUint returns a uniform value in [0, n], reading as few bytes as
+// hold n and redrawing values above it.
+func randomUint(source io.Reader, n uint64) (uint64, error) {
+	width := bits.Len64(n)
+	mask := uint64(1)<<width - 1
+	var buf [8]byte
+	for {
+		if _, err := io.ReadFull(source, buf[8-(width+7)/8:]); err != nil {
+			return 0, fmt.Errorf("yid: read random source: %w", err)
+		}
+		if v := binary.BigEndian.Uint64(buf[:]) & mask; v <= n {
+			return v, nil
+		}
+	}
+}
+
+// CollisionProbability returns the probability that at least two of count
+// random strings of the given length, drawn uniformly from an alphabet of
+// alphabetSize characters, are equal (the birthday bound).
+//
+// Example:
+//
+//	yid.CollisionProbability(62, 8, 1_000_000) // -> ~0.0023
+func CollisionProbability(alphabetSize, length, count int) float64 {
+	if count < 2 {
+		return 0
+	}
+	if alphabetSize < 2 || length < 1 {
+		return 1
+	}
+	space := math.Pow(float64(alphabetSize), float64(length))
+	if float64(count) > space {
+		return 1
+	}
+	pairs := float64(count) * float64(count-1) / 2
+	return -math.Expm1(-pairs / space)
+}
@@ -0,0 +1,197 @@
+package yid_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestRandom_Length tests that Random returns IDs with the requested number
+// of dictionary characters.
+func TestRandom_Length(t *testing.T) {
+	enc := yid.New()
+	for _, length := range []int{1, 8, 11} {
+		code, err := enc.Random(length)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(code) != length {
+			t.Errorf("expected length %d, got %d", length, len(code))
+		}
+		for _, c := range code {
+			if !strings.ContainsRune(dictionary, c) {
+				t.Errorf("invalid character '%c' in '%s'", c, code)
+			}
+		}
+	}
+}
+
+// TestRandom_InvalidLength tests that lengths no number is written with are rejected.
+func TestRandom_InvalidLength(t *testing.T) {
+	tests := map[string]struct {
+		enc     *yid.Encoder
+		lengths []int
+	}{
+		"default":  {yid.New(), []int{0, -1, 12}},
+		"padUp":    {yid.New(yid.WithPadUp(3)), []int{1, 2}},
+		"sortable": {yid.New(yid.WithSortable()), []int{1, 10, 12}},
+	}
+	for name, tt := range tests {
+		for _, length := range tt.lengths {
+			if _, err := tt.enc.Random(length); !errors.Is(err, yid.ErrInvalidLength) {
+				t.Errorf("%s: length %d: expected ErrInvalidLength, got %v", name, length, err)
+			}
+		}
+	}
+}
+
+// TestRandom_Valid tests that Random returns IDs that Valid accepts, with
+// the encoder's prefix and signature around length digits.
+func TestRandom_Valid(t *testing.T) {
+	tests := map[string]struct {
+		opts   []yid.Option
+		prefix string
+		sigLen int
+	}{
+		"default":   {nil, "", 0},
+		"secure":    {[]yid.Option{yid.WithSecureKey("secret")}, "", 0},
+		"padUp":     {[]yid.Option{yid.WithPadUp(3)}, "", 0},
+		"signed":    {[]yid.Option{yid.WithPrefix("inv_"), yid.WithSignature([]byte("hmac-secret"), 6)}, "inv_", 6},
+		"sortable":  {[]yid.Option{yid.WithSortable()}, "", 0},
+		"crockford": {[]yid.Option{yid.WithAlphabet(yid.CrockfordAlphabet), yid.WithGrouping(4, "-")}, "", 0},
+		"binary":    {[]yid.Option{yid.WithAlphabet("01")}, "", 0},
+	}
+	for name, tt := range tests {
+		enc := yid.New(tt.opts...)
+		generated := 0
+		for length := 1; length <= 64; length++ {
+			for i := 0; i < 20; i++ {
+				code, err := enc.Random(length)
+				if errors.Is(err, yid.ErrInvalidLength) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", name, err)
+				}
+				generated++
+				if err := enc.Validate(code); err != nil {
+					t.Errorf("%s: Validate(%q) = %v", name, code, err)
+				}
+				if !strings.HasPrefix(code, tt.prefix) || len(code) != len(tt.prefix)+length+tt.sigLen {
+					t.Errorf("%s: expected prefix %q and %d digits, got %q", name, tt.prefix, length, code)
+				}
+			}
+		}
+		if generated == 0 {
+			t.Errorf("%s: no length was accepted", name)
+		}
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+// TestRandom_Deterministic tests that a fixed source produces a fixed result
+// and that only the bytes needed are read.
+func TestRandom_Deterministic(t *testing.T) {
+	tests := []struct {
+		length   int
+		source   []byte
+		expected string
+		read     int
+	}{
+		{1, []byte{0}, "a", 1},
+		{1, []byte{61}, "Z", 1},
+		{3, []byte{0, 0, 0}, "baa", 3},
+		{8, make([]byte, 6), "baaaaaaa", 6},
+	}
+	for _, tt := range tests {
+		source := &countingReader{r: bytes.NewReader(append(tt.source, bytes.Repeat([]byte{0xff}, 64)...))}
+		enc := yid.New(yid.WithRandSource(source))
+		code, err := enc.Random(tt.length)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if code != tt.expected {
+			t.Errorf("length %d: expected %q, got %q", tt.length, tt.expected, code)
+		}
+		if source.n != tt.read {
+			t.Errorf("length %d: expected %d bytes read, got %d", tt.length, tt.read, source.n)
+		}
+	}
+}
+
+// TestRandom_RejectsOutOfRange tests that values outside the range are redrawn.
+func TestRandom_RejectsOutOfRange(t *testing.T) {
+	// With 6 bits per draw, 62 and 63 are out of range for one digit.
+	enc := yid.New(yid.WithRandSource(bytes.NewReader([]byte{62, 255, 5})))
+	code, err := enc.Random(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != "f" {
+		t.Errorf("expected 'f', got '%s'", code)
+	}
+}
+
+// TestRandom_UsesSecureDictionary tests that Random draws from the shuffled dictionary.
+func TestRandom_UsesSecureDictionary(t *testing.T) {
+	enc := yid.New(yid.WithSecureKey("secret"), yid.WithRandSource(bytes.NewReader([]byte{0})))
+	code, err := enc.Random(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zero, err := enc.EncodeRaw(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != zero {
+		t.Errorf("expected '%s', got '%s'", zero, code)
+	}
+}
+
+// TestRandom_SourceError tests that read errors are returned.
+func TestRandom_SourceError(t *testing.T) {
+	enc := yid.New(yid.WithRandSource(bytes.NewReader(nil)))
+	if _, err := enc.Random(8); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+// TestCollisionProbability tests the birthday bound against known values.
+func TestCollisionProbability(t *testing.T) {
+	tests := []struct {
+		name                        string
+		alphabetSize, length, count int
+		expected                    float64
+	}{
+		{"single item", 62, 8, 1, 0},
+		{"no items", 62, 8, 0, 0},
+		{"million 8-char codes", 62, 8, 1_000_000, 0.0022873829580687443},
+		{"pigeonhole", 2, 1, 3, 1},
+		{"empty alphabet", 0, 8, 10, 1},
+		{"zero length", 62, 0, 10, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := yid.CollisionProbability(tt.alphabetSize, tt.length, tt.count)
+			if math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package yid
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrUnknownEncoder is returned when no encoder is registered under a name.
+var ErrUnknownEncoder = errors.New("yid: unknown encoder")
+
+// ErrNoMasterSecret is returned when deriving a key or an encoder without a
+// master secret, including from a registry created without one.
+var ErrNoMasterSecret = errors.New("yid: no master secret")
+
+// Registry holds encoders by name, such as one per entity type or tenant
+// ("user", "tenant:42/order"). Encoders can be registered directly or
+// derived from the registry's master secret, so that every name gets its own
+// shuffled dictionary while only one secret has to be managed.
+// A Registry is safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	reg := yid.NewRegistry(masterSecret)
+//	users, _ := reg.Derive("user", yid.WithPadUp(4))
+//	orders, _ := reg.Derive("tenant:42/order")
+//	enc, ok := reg.Lookup("user") // -> users, true
+type Registry struct {
+	mu       sync.RWMutex
+	encoders map[string]*Encoder
+	master   []byte
+}
+
+// defaultRegistry holds the registry returned by DefaultRegistry.
+var defaultRegistry atomic.Pointer[Registry]
+
+func init() {
+	defaultRegistry.Store(NewRegistry(nil))
+}
+
+// DefaultRegistry returns the registry used by Register, Lookup and struct
+// tags. It starts empty and without a master secret, so Derive on it returns
+// ErrNoMasterSecret until SetDefaultRegistry installs one that has a secret.
+func DefaultRegistry() *Registry {
+	return defaultRegistry.Load()
+}
+
+// SetDefaultRegistry replaces the registry used by Register, Lookup and
+// struct tags. It is safe to call concurrently with them, but encoders
+// registered in the previous registry are no longer found, so call it at
+// program start. A nil registry installs a new empty one.
+//
+// Example:
+//
+//	yid.SetDefaultRegistry(yid.NewRegistry(masterSecret))
+//	users, _ := yid.DefaultRegistry().Derive("user")
+func SetDefaultRegistry(r *Registry) {
+	if r == nil {
+		r = NewRegistry(nil)
+	}
+	defaultRegistry.Store(r)
+}
+
+// NewRegistry creates an empty Registry. master is the secret that derived
+// encoders are keyed from; it may be nil if encoders are only registered,
+// in which case Derive returns ErrNoMasterSecret.
+func NewRegistry(master []byte) *Registry {
+	return &Registry{
+		encoders: make(map[string]*Encoder),
+		master:   append([]byte(nil), master...),
+	}
+}
+
+// Register makes enc available under name.
+// Registering a name again replaces the previous encoder.
+func (r *Registry) Register(name string, enc *Encoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.encoders[name] = enc
+}
+
+// Lookup returns the encoder registered under name.
+func (r *Registry) Lookup(name string) (*Encoder, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	enc, ok := r.encoders[name]
+	return enc, ok
+}
+
+// Derive creates an encoder with the given options whose secure key is
+// derived from the master secret with name as context (see DeriveKey), registers it under
+// name and returns it. The derived key replaces any WithSecureKey option.
+// The encoder is named after name for observers unless opts set WithName.
+// Returns ErrNoMasterSecret if the registry has no master secret.
+func (r *Registry) Derive(name string, opts ...Option) (*Encoder, error) {
+	if len(r.master) == 0 {
+		return nil, ErrNoMasterSecret
+	}
+	opts = append([]Option{WithName(name)}, opts...)
+	opts = append(opts, WithDerivedKey(r.master, name))
+	enc := New(opts...)
+	r.Register(name, enc)
+	return enc, nil
+}
+
+// Names returns the registered names in sorted order, for diagnostics.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.encoders))
+	for name := range r.encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Register makes enc available under name in DefaultRegistry.
+//
+// Example:
+//
+//	yid.Register("usr", yid.New(yid.WithSecureKey("users")))
+func Register(name string, enc *Encoder) {
+	DefaultRegistry().Register(name, enc)
+}
+
+// Lookup returns the encoder registered under name in DefaultRegistry.
+func Lookup(name string) (*Encoder, bool) {
+	return DefaultRegistry().Lookup(name)
+}
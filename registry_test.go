@@ -0,0 +1,170 @@
+package yid_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestRegister_DefaultRegistry tests registering and looking up encoders by name.
+func TestRegister_DefaultRegistry(t *testing.T) {
+	enc := yid.New(yid.WithPadUp(3))
+	yid.Register("test:registry", enc)
+	got, ok := yid.Lookup("test:registry")
+	if !ok || got != enc {
+		t.Error("expected registered encoder")
+	}
+	if _, ok := yid.Lookup("test:missing"); ok {
+		t.Error("expected no encoder for unknown name")
+	}
+}
+
+// TestSetDefaultRegistry tests that replacing the default registry is safe
+// while it is in use.
+func TestSetDefaultRegistry(t *testing.T) {
+	prev := yid.DefaultRegistry()
+	t.Cleanup(func() { yid.SetDefaultRegistry(prev) })
+
+	if _, err := yid.DefaultRegistry().Derive("user"); !errors.Is(err, yid.ErrNoMasterSecret) {
+		t.Errorf("expected ErrNoMasterSecret from the initial registry, got %v", err)
+	}
+
+	reg := yid.NewRegistry([]byte("master-secret"))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			yid.SetDefaultRegistry(reg)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			yid.Register(fmt.Sprintf("test:set-default/%d", i), yid.New())
+			yid.Lookup("test:set-default/0")
+		}(i)
+	}
+	wg.Wait()
+
+	if yid.DefaultRegistry() != reg {
+		t.Fatal("expected the installed registry")
+	}
+	users, err := yid.DefaultRegistry().Derive("user")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := yid.Lookup("user"); !ok || got != users {
+		t.Error("expected Lookup to use the installed registry")
+	}
+
+	yid.SetDefaultRegistry(nil)
+	if yid.DefaultRegistry() == nil {
+		t.Error("expected SetDefaultRegistry(nil) to install an empty registry")
+	}
+	if _, ok := yid.Lookup("user"); ok {
+		t.Error("expected the empty registry not to hold earlier encoders")
+	}
+}
+
+// TestRegistry_Derive tests that derived encoders are deterministic and distinct per name.
+func TestRegistry_Derive(t *testing.T) {
+	reg := yid.NewRegistry([]byte("master-secret"))
+	users, err := reg.Derive("user")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	orders, err := reg.Derive("tenant:42/order")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	u, _ := users.Encode(12345)
+	o, _ := orders.Encode(12345)
+	plain, _ := yid.ToAlphanumeric(12345)
+	if u == o || u == plain || o == plain {
+		t.Errorf("expected distinct encodings, got %s, %s and %s", u, o, plain)
+	}
+
+	again, err := yid.NewRegistry([]byte("master-secret")).Derive("user")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u2, _ := again.Encode(12345); u2 != u {
+		t.Errorf("expected deterministic derivation, got %s and %s", u, u2)
+	}
+
+	other, err := yid.NewRegistry([]byte("other-secret")).Derive("user")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u3, _ := other.Encode(12345); u3 == u {
+		t.Error("expected different master secrets to derive different encoders")
+	}
+
+	if got, ok := reg.Lookup("user"); !ok || got != users {
+		t.Error("expected derived encoder to be registered")
+	}
+}
+
+// TestRegistry_DeriveOptions tests that options are applied and the derived key wins.
+func TestRegistry_DeriveOptions(t *testing.T) {
+	reg := yid.NewRegistry([]byte("master-secret"))
+	padded, err := reg.Derive("padded", yid.WithPadUp(5), yid.WithSecureKey("ignored"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	encoded, _ := padded.Encode(1)
+	if len(encoded) != 5 {
+		t.Errorf("expected padded output of length 5, got '%s'", encoded)
+	}
+	withIgnoredKey, _ := yid.ToAlphanumeric(1, yid.WithPadUp(5), yid.WithSecureKey("ignored"))
+	if encoded == withIgnoredKey {
+		t.Error("expected derived key to replace WithSecureKey")
+	}
+}
+
+// TestRegistry_NoMasterSecret tests that deriving without a master secret fails.
+func TestRegistry_NoMasterSecret(t *testing.T) {
+	if _, err := yid.NewRegistry(nil).Derive("user"); !errors.Is(err, yid.ErrNoMasterSecret) {
+		t.Errorf("expected ErrNoMasterSecret, got %v", err)
+	}
+}
+
+// TestRegistry_Names tests that names are enumerated in sorted order.
+func TestRegistry_Names(t *testing.T) {
+	reg := yid.NewRegistry(nil)
+	for _, name := range []string{"user", "order", "tenant:1/user"} {
+		reg.Register(name, yid.New())
+	}
+	expected := []string{"order", "tenant:1/user", "user"}
+	if names := reg.Names(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+// TestRegistry_Concurrent tests concurrent registration and lookup.
+func TestRegistry_Concurrent(t *testing.T) {
+	reg := yid.NewRegistry([]byte("master-secret"))
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("tenant:%d/user", i)
+			if _, err := reg.Derive(name); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if _, ok := reg.Lookup(name); !ok {
+				t.Errorf("expected %s to be registered", name)
+			}
+			_ = reg.Names()
+		}(i)
+	}
+	wg.Wait()
+	if len(reg.Names()) != 16 {
+		t.Errorf("expected 16 names, got %d", len(reg.Names()))
+	}
+}
@@ -0,0 +1,143 @@
+// Package rpcx converts ID fields between internal RPC messages, which carry
+// int64 IDs, and public gateway messages, which carry yid strings.
+//
+// A Converter walks a message with reflection and copies it field by field
+// into a message of a different type. Fields with the same name are paired;
+// ID fields (by naming convention or an explicit list) are encoded or decoded
+// along the way, including inside nested messages, pointers, slices and maps.
+// Only exported fields are visited, so protoc-generated Go structs work as is
+// and their internal state is never copied.
+//
+// The package has no gRPC dependency: use a Converter inside your own
+// interceptors, or wrap a method with Bridge.
+//
+// Example:
+//
+//	conv := rpcx.New(yid.New(yid.WithSecureKey("my-secret")))
+//	getUser := rpcx.Bridge[pb.PublicGetUserRequest, pb.PublicUser](conv, internal.GetUser)
+//	resp, err := getUser(ctx, &pb.PublicGetUserRequest{UserId: "hQj"})
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/wow-apps/youtube-id-go/internal/convert"
+)
+
+// ErrTypeMismatch is returned when paired fields have incompatible types.
+var ErrTypeMismatch = convert.ErrTypeMismatch
+
+// Codec encodes and decodes IDs. *yid.Encoder implements it.
+type Codec = convert.Codec
+
+// config holds converter configuration.
+type config struct {
+	match func(name string) bool
+}
+
+// Option configures a Converter.
+type Option func(*config)
+
+// WithFields converts only the fields with the given names instead of using
+// the naming convention.
+func WithFields(names ...string) Option {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return func(c *config) {
+		c.match = func(name string) bool { return set[name] }
+	}
+}
+
+// WithMatcher sets a function that reports whether a field holds an ID.
+func WithMatcher(match func(name string) bool) Option {
+	return func(c *config) {
+		c.match = match
+	}
+}
+
+// IsIDField is the default naming convention: a field holds an ID if its
+// name is or ends in "Id"/"ID", or "Ids"/"IDs" for repeated fields.
+func IsIDField(name string) bool {
+	for _, suffix := range []string{"Id", "ID", "Ids", "IDs"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Converter copies messages while converting ID fields.
+// A Converter is safe for concurrent use by multiple goroutines.
+type Converter struct {
+	codec Codec
+	match func(name string) bool
+}
+
+// New creates a Converter that encodes and decodes IDs with codec.
+func New(codec Codec, opts ...Option) *Converter {
+	cfg := config{match: IsIDField}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Converter{codec: codec, match: cfg.match}
+}
+
+// ToPublic copies the internal message src into the public message dst,
+// encoding int64 ID fields into strings. dst must be a non-nil pointer.
+func (c *Converter) ToPublic(dst, src any) error {
+	return c.convert(dst, src, convert.Encode)
+}
+
+// ToInternal copies the public message src into the internal message dst,
+// decoding string ID fields into int64. dst must be a non-nil pointer.
+func (c *Converter) ToInternal(dst, src any) error {
+	return c.convert(dst, src, convert.Decode)
+}
+
+// Bridge adapts an internal method taking and returning int64-ID messages
+// into a public method taking and returning yid-string messages. The request
+// is converted with ToInternal and the response with ToPublic; errors from
+// call are returned unchanged.
+func Bridge[PubReq, PubResp, IntReq, IntResp any](c *Converter, call func(context.Context, *IntReq) (*IntResp, error)) func(context.Context, *PubReq) (*PubResp, error) {
+	return func(ctx context.Context, req *PubReq) (*PubResp, error) {
+		internalReq := new(IntReq)
+		if err := c.ToInternal(internalReq, req); err != nil {
+			return nil, err
+		}
+		internalResp, err := call(ctx, internalReq)
+		if err != nil {
+			return nil, err
+		}
+		resp := new(PubResp)
+		if err := c.ToPublic(resp, internalResp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+}
+
+// convert validates the arguments and copies src into dst.
+func (c *Converter) convert(dst, src any, dir convert.Direction) error {
+	d := reflect.ValueOf(dst)
+	if d.Kind() != reflect.Pointer || d.IsNil() {
+		return fmt.Errorf("rpcx: destination must be a non-nil pointer, got %T", dst)
+	}
+	err := convert.Copy(d.Elem(), reflect.ValueOf(src), dir, c.field, d.Elem().Type().Name())
+	if err != nil {
+		return fmt.Errorf("rpcx: %w", err)
+	}
+	return nil
+}
+
+// field selects the codec for fields matching the naming convention.
+func (c *Converter) field(dst, _ reflect.StructField) (convert.Codec, error) {
+	if c.match(dst.Name) {
+		return c.codec, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,263 @@
+package rpcx_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/rpc"
+	"reflect"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/rpcx"
+)
+
+// Internal messages use int64 IDs.
+type GetUserRequest struct {
+	UserId int64
+}
+
+type User struct {
+	UserId    int64
+	OrgId     int64
+	Name      string
+	FriendIds []int64
+	Manager   *User
+	Tags      map[string]int64
+
+	sizeCache int32
+}
+
+// Public messages use yid strings.
+type PublicGetUserRequest struct {
+	UserId string
+}
+
+type PublicUser struct {
+	UserId    string
+	OrgId     string
+	Name      string
+	FriendIds []string
+	Manager   *PublicUser
+	Tags      map[string]int64
+}
+
+// Users is an internal service exposed over net/rpc.
+type Users struct{}
+
+// Get returns a user with related IDs derived from the requested one.
+func (Users) Get(req *GetUserRequest, resp *User) error {
+	if req.UserId == 0 {
+		return errors.New("user not found")
+	}
+	*resp = User{
+		UserId:    req.UserId,
+		OrgId:     7,
+		Name:      "Ada",
+		FriendIds: []int64{req.UserId + 1, req.UserId + 2},
+		Manager:   &User{UserId: 1, Name: "Root"},
+		Tags:      map[string]int64{"level": 3},
+	}
+	return nil
+}
+
+// newClient starts an in-process net/rpc server and returns a connected client.
+func newClient(t *testing.T) *rpc.Client {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.Register(Users{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	serverConn, clientConn := net.Pipe()
+	go server.ServeConn(serverConn)
+	client := rpc.NewClient(clientConn)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// TestBridge_InProcessServer tests a public gateway method in front of an internal RPC server.
+func TestBridge_InProcessServer(t *testing.T) {
+	enc := yid.New(yid.WithSecureKey("secret"))
+	client := newClient(t)
+	conv := rpcx.New(enc)
+
+	getUser := rpcx.Bridge[PublicGetUserRequest, PublicUser](conv,
+		func(ctx context.Context, req *GetUserRequest) (*User, error) {
+			var resp User
+			if err := client.Call("Users.Get", req, &resp); err != nil {
+				return nil, err
+			}
+			return &resp, nil
+		})
+
+	userID, _ := enc.EncodeRaw(100)
+	resp, err := getUser(context.Background(), &PublicGetUserRequest{UserId: userID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encode := func(n int64) string {
+		s, err := enc.EncodeRaw(n)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return s
+	}
+	expected := &PublicUser{
+		UserId:    userID,
+		OrgId:     encode(7),
+		Name:      "Ada",
+		FriendIds: []string{encode(101), encode(102)},
+		Manager:   &PublicUser{UserId: encode(1), OrgId: encode(0), Name: "Root"},
+		Tags:      map[string]int64{"level": 3},
+	}
+	if !reflect.DeepEqual(resp, expected) {
+		t.Errorf("expected %+v, got %+v", expected, resp)
+	}
+
+	if _, err := getUser(context.Background(), &PublicGetUserRequest{UserId: encode(0)}); err == nil {
+		t.Error("expected error from server")
+	}
+	if _, err := getUser(context.Background(), &PublicGetUserRequest{UserId: "a-b"}); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter, got %v", err)
+	}
+}
+
+// TestConverter_Roundtrip tests that ToInternal reverses ToPublic.
+func TestConverter_Roundtrip(t *testing.T) {
+	conv := rpcx.New(yid.New())
+	original := User{UserId: 12345, FriendIds: []int64{1, 2}, Manager: &User{UserId: 3}}
+
+	var public PublicUser
+	if err := conv.ToPublic(&public, &original); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if public.UserId != "dnh" {
+		t.Errorf("expected 'dnh', got '%s'", public.UserId)
+	}
+
+	var back User
+	if err := conv.ToInternal(&back, public); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(back, original) {
+		t.Errorf("expected %+v, got %+v", original, back)
+	}
+}
+
+// TestConverter_WithFields tests converting an explicit list of fields.
+func TestConverter_WithFields(t *testing.T) {
+	type internal struct {
+		Owner int64
+		Count int64
+	}
+	type public struct {
+		Owner string
+		Count int64
+	}
+	conv := rpcx.New(yid.New(), rpcx.WithFields("Owner"))
+	var out public
+	if err := conv.ToPublic(&out, internal{Owner: 12345, Count: 9}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Owner != "dnh" || out.Count != 9 {
+		t.Errorf("unexpected result: %+v", out)
+	}
+}
+
+// TestConverter_WithMatcher tests a custom field matcher.
+func TestConverter_WithMatcher(t *testing.T) {
+	type internal struct{ Ref int64 }
+	type public struct{ Ref string }
+	conv := rpcx.New(yid.New(), rpcx.WithMatcher(func(name string) bool { return name == "Ref" }))
+	var out public
+	if err := conv.ToPublic(&out, &internal{Ref: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Ref != "b" {
+		t.Errorf("expected 'b', got '%s'", out.Ref)
+	}
+}
+
+// TestConverter_NilValues tests that nil pointers, slices and maps stay nil.
+func TestConverter_NilValues(t *testing.T) {
+	conv := rpcx.New(yid.New())
+	out := PublicUser{Manager: &PublicUser{}, FriendIds: []string{"x"}, Tags: map[string]int64{"x": 1}}
+	if err := conv.ToPublic(&out, &User{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Manager != nil || out.FriendIds != nil || out.Tags != nil {
+		t.Errorf("expected nil fields, got %+v", out)
+	}
+	if err := conv.ToPublic(&out, nil); err != nil {
+		t.Errorf("unexpected error for nil source: %v", err)
+	}
+	var nilUser *User
+	if err := conv.ToPublic(&out, nilUser); err != nil {
+		t.Errorf("unexpected error for nil pointer source: %v", err)
+	}
+}
+
+// TestConverter_PointerFields tests ID fields behind pointers on either side.
+func TestConverter_PointerFields(t *testing.T) {
+	type internal struct{ ParentId *int64 }
+	type public struct{ ParentId string }
+	conv := rpcx.New(yid.New())
+	n := int64(62)
+	var out public
+	if err := conv.ToPublic(&out, internal{ParentId: &n}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ParentId != "ba" {
+		t.Errorf("expected 'ba', got '%s'", out.ParentId)
+	}
+
+	var back internal
+	if err := conv.ToInternal(&back, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if back.ParentId == nil || *back.ParentId != 62 {
+		t.Errorf("expected 62, got %v", back.ParentId)
+	}
+}
+
+// TestConverter_Errors tests invalid destinations, type mismatches and encode failures.
+func TestConverter_Errors(t *testing.T) {
+	conv := rpcx.New(yid.New())
+
+	var out PublicUser
+	if err := conv.ToPublic(out, &User{}); err == nil {
+		t.Error("expected error for non-pointer destination")
+	}
+
+	type mismatch struct{ Name int }
+	if err := conv.ToPublic(&mismatch{}, &User{Name: "x"}); !errors.Is(err, rpcx.ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch, got %v", err)
+	}
+
+	if err := conv.ToPublic(&out, &User{FriendIds: []int64{-1}}); !errors.Is(err, yid.ErrNegativeNumber) {
+		t.Errorf("expected ErrNegativeNumber, got %v", err)
+	}
+
+	if err := conv.ToInternal(&User{}, &PublicUser{Tags: map[string]int64{"a": 1}, Manager: &PublicUser{UserId: "!"}}); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter, got %v", err)
+	}
+
+	type idMap struct{ OwnerIds map[string]int64 }
+	type publicIDMap struct{ OwnerIds map[string]string }
+	if err := conv.ToPublic(&publicIDMap{}, idMap{OwnerIds: map[string]int64{"a": -1}}); !errors.Is(err, yid.ErrNegativeNumber) {
+		t.Errorf("expected ErrNegativeNumber from map value, got %v", err)
+	}
+}
+
+// TestIsIDField tests the default naming convention.
+func TestIsIDField(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"Id": true, "UserId": true, "OrgID": true, "FriendIds": true, "MemberIDs": true,
+		"Name": false, "Idle": false, "Video": false,
+	} {
+		if got := rpcx.IsIDField(name); got != expected {
+			t.Errorf("IsIDField(%q) = %v, want %v", name, got, expected)
+		}
+	}
+}
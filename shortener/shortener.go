@@ -0,0 +1,201 @@
+// Package shortener is a reference URL shortener built on yid.Encoder.
+//
+// Links are stored behind the Store interface under sequential numbers and
+// published as encoded codes. The Server handles:
+//
+//	POST /api/links         create a link from {"url": "..."}
+//	GET  /api/links/{code}  return a link with its hit counter
+//	GET  /{code}            redirect to the long URL and count the hit
+//
+// Example:
+//
+//	srv := shortener.New(shortener.NewMemoryStore(), yid.New(yid.WithSecureKey("my-secret")),
+//		shortener.WithBaseURL("https://sho.rt"))
+//	http.ListenAndServe(":8080", srv)
+package shortener
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/httpx"
+)
+
+// MaxURLLength is the longest URL accepted by POST /api/links.
+const MaxURLLength = 2048
+
+// LinkResponse is the JSON representation of a link returned by the API.
+type LinkResponse struct {
+	Code      string    `json:"code"`
+	ShortURL  string    `json:"short_url"`
+	URL       string    `json:"url"`
+	Hits      int64     `json:"hits"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// createRequest is the body of POST /api/links.
+type createRequest struct {
+	URL string `json:"url"`
+}
+
+// config holds server configuration.
+type config struct {
+	baseURL string
+}
+
+// Option configures a Server.
+type Option func(*config)
+
+// WithBaseURL sets the prefix of short URLs returned by the API (for
+// example "https://sho.rt"). Defaults to the scheme and host of the request.
+func WithBaseURL(baseURL string) Option {
+	return func(c *config) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// Server serves the shortener HTTP API and redirects.
+type Server struct {
+	store   Store
+	enc     *yid.Encoder
+	baseURL string
+	mux     *http.ServeMux
+}
+
+// New creates a Server storing links in store and encoding their IDs with enc.
+func New(store Store, enc *yid.Encoder, opts ...Option) *Server {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Server{
+		store:   store,
+		enc:     enc,
+		baseURL: cfg.baseURL,
+		mux:     http.NewServeMux(),
+	}
+	decode := httpx.Middleware(enc, "code")
+	s.mux.HandleFunc("POST /api/links", s.handleCreate)
+	s.mux.Handle("GET /api/links/{code}", decode(http.HandlerFunc(s.handleGet)))
+	s.mux.Handle("GET /{code}", decode(http.HandlerFunc(s.handleRedirect)))
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleCreate stores a new link.
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxURLLength+1024)).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if err := validateURL(req.URL); err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link, err := s.store.Create(r.Context(), req.URL)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "could not store link")
+		return
+	}
+	s.writeLink(w, r, http.StatusCreated, link)
+}
+
+// handleGet returns a link and its hit counter.
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	id, _ := httpx.FromContext(r.Context(), "code")
+	link, err := s.store.Get(r.Context(), id)
+	if err != nil {
+		s.writeStoreError(w, r, err)
+		return
+	}
+	s.writeLink(w, r, http.StatusOK, link)
+}
+
+// handleRedirect counts a hit and redirects to the long URL.
+func (s *Server) handleRedirect(w http.ResponseWriter, r *http.Request) {
+	id, _ := httpx.FromContext(r.Context(), "code")
+	link, err := s.store.Hit(r.Context(), id)
+	if err != nil {
+		s.writeStoreError(w, r, err)
+		return
+	}
+	http.Redirect(w, r, link.URL, http.StatusFound)
+}
+
+// writeLink writes link as a LinkResponse.
+func (s *Server) writeLink(w http.ResponseWriter, r *http.Request, status int, link Link) {
+	code, err := s.enc.EncodeRaw(link.ID)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "could not encode link ID")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(LinkResponse{
+		Code:      code,
+		ShortURL:  s.shortURL(r, code),
+		URL:       link.URL,
+		Hits:      link.Hits,
+		CreatedAt: link.CreatedAt,
+	})
+}
+
+// writeStoreError maps store errors to problem responses.
+func (s *Server) writeStoreError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, "link not found")
+		return
+	}
+	writeError(w, r, http.StatusInternalServerError, "could not load link")
+}
+
+// shortURL returns the public URL for code.
+func (s *Server) shortURL(r *http.Request, code string) string {
+	if s.baseURL != "" {
+		return s.baseURL + "/" + code
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, r.Host, code)
+}
+
+// writeError writes a problem response.
+func writeError(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	httpx.WriteProblem(w, httpx.Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
+
+// validateURL accepts absolute http and https URLs.
+func validateURL(raw string) error {
+	if raw == "" {
+		return errors.New("url is required")
+	}
+	if len(raw) > MaxURLLength {
+		return fmt.Errorf("url is longer than %d characters", MaxURLLength)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	return nil
+}
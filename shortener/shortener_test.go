@@ -0,0 +1,167 @@
+package shortener_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/shortener"
+)
+
+// create posts body to /api/links.
+func create(t *testing.T, h http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/links", strings.NewReader(body))
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// get performs a GET request against h.
+func get(h http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+// TestServer_CreateRedirectStats tests the full link lifecycle.
+func TestServer_CreateRedirectStats(t *testing.T) {
+	enc := yid.New(yid.WithSecureKey("secret"), yid.WithPadUp(3))
+	srv := shortener.New(shortener.NewMemoryStore(), enc, shortener.WithBaseURL("https://sho.rt/"))
+
+	rec := create(t, srv, `{"url": "https://example.com/long/path"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	var created shortener.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id, err := enc.Decode(created.Code); err != nil || id != 1 {
+		t.Errorf("expected code for ID 1, got %s (%d, %v)", created.Code, id, err)
+	}
+	if created.ShortURL != "https://sho.rt/"+created.Code {
+		t.Errorf("unexpected short URL: %s", created.ShortURL)
+	}
+
+	for i := 0; i < 2; i++ {
+		rec = get(srv, "/"+created.Code)
+		if rec.Code != http.StatusFound {
+			t.Fatalf("expected 302, got %d", rec.Code)
+		}
+		if loc := rec.Header().Get("Location"); loc != "https://example.com/long/path" {
+			t.Errorf("unexpected Location: %s", loc)
+		}
+	}
+
+	rec = get(srv, "/api/links/"+created.Code)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var stats shortener.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&stats); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Hits != 2 {
+		t.Errorf("expected 2 hits, got %d", stats.Hits)
+	}
+}
+
+// TestServer_ShortURLFromRequest tests that short URLs default to the request host.
+func TestServer_ShortURLFromRequest(t *testing.T) {
+	srv := shortener.New(shortener.NewMemoryStore(), yid.New())
+	rec := create(t, srv, `{"url": "http://example.com"}`)
+	var created shortener.LinkResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.ShortURL != "http://example.com/b" {
+		t.Errorf("unexpected short URL: %s", created.ShortURL)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "https://sho.rt/api/links", strings.NewReader(`{"url": "http://example.com"}`))
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.ShortURL != "https://sho.rt/c" {
+		t.Errorf("unexpected short URL: %s", created.ShortURL)
+	}
+}
+
+// TestServer_CreateInvalid tests that invalid requests are rejected.
+func TestServer_CreateInvalid(t *testing.T) {
+	srv := shortener.New(shortener.NewMemoryStore(), yid.New())
+	for _, body := range []string{
+		`{`,
+		`{"url": ""}`,
+		`{"url": "ftp://example.com"}`,
+		`{"url": "/relative"}`,
+		`{"url": "https://example.com/` + strings.Repeat("a", shortener.MaxURLLength) + `"}`,
+	} {
+		if rec := create(t, srv, body); rec.Code != http.StatusBadRequest {
+			t.Errorf("body %.40s: expected 400, got %d", body, rec.Code)
+		}
+	}
+}
+
+// TestServer_NotFound tests unknown and malformed codes.
+func TestServer_NotFound(t *testing.T) {
+	srv := shortener.New(shortener.NewMemoryStore(), yid.New())
+	for _, path := range []string{"/zz", "/a-b", "/api/links/zz"} {
+		if rec := get(srv, path); rec.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", path, rec.Code)
+		}
+	}
+}
+
+// failingStore is a Store whose operations fail.
+type failingStore struct{}
+
+var errStore = errors.New("store unavailable")
+
+func (failingStore) Create(context.Context, string) (shortener.Link, error) {
+	return shortener.Link{}, errStore
+}
+
+func (failingStore) Get(context.Context, int64) (shortener.Link, error) {
+	return shortener.Link{}, errStore
+}
+
+func (failingStore) Hit(context.Context, int64) (shortener.Link, error) {
+	return shortener.Link{}, errStore
+}
+
+// TestServer_StoreErrors tests that store failures return 500.
+func TestServer_StoreErrors(t *testing.T) {
+	srv := shortener.New(failingStore{}, yid.New())
+	if rec := create(t, srv, `{"url": "https://example.com"}`); rec.Code != http.StatusInternalServerError {
+		t.Errorf("create: expected 500, got %d", rec.Code)
+	}
+	for _, path := range []string{"/b", "/api/links/b"} {
+		if rec := get(srv, path); rec.Code != http.StatusInternalServerError {
+			t.Errorf("%s: expected 500, got %d", path, rec.Code)
+		}
+	}
+}
+
+// overflowStore returns links whose IDs cannot be encoded.
+type overflowStore struct{ failingStore }
+
+func (overflowStore) Create(context.Context, string) (shortener.Link, error) {
+	return shortener.Link{ID: -1}, nil
+}
+
+// TestServer_EncodeError tests that unencodable IDs return 500.
+func TestServer_EncodeError(t *testing.T) {
+	srv := shortener.New(overflowStore{}, yid.New())
+	if rec := create(t, srv, `{"url": "https://example.com"}`); rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,240 @@
+package shortener
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when no link exists for an ID.
+var ErrNotFound = errors.New("shortener: link not found")
+
+// Link is a stored short link.
+type Link struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Hits      int64     `json:"hits"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store persists links and allocates their sequential IDs.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Create stores url under the next sequential ID.
+	Create(ctx context.Context, url string) (Link, error)
+	// Get returns the link with the given ID, or ErrNotFound.
+	Get(ctx context.Context, id int64) (Link, error)
+	// Hit increments the hit counter of the link with the given ID and
+	// returns the updated link, or ErrNotFound.
+	Hit(ctx context.Context, id int64) (Link, error)
+}
+
+// MemoryStore is an in-memory Store. IDs start at 1.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	links []Link
+	now   func() time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now}
+}
+
+// Create stores url under the next sequential ID.
+func (s *MemoryStore) Create(_ context.Context, url string) (Link, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.create(url), nil
+}
+
+// Get returns the link with the given ID, or ErrNotFound.
+func (s *MemoryStore) Get(_ context.Context, id int64) (Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i, ok := s.index(id)
+	if !ok {
+		return Link{}, ErrNotFound
+	}
+	return s.links[i], nil
+}
+
+// Hit increments the hit counter of the link with the given ID.
+func (s *MemoryStore) Hit(_ context.Context, id int64) (Link, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hit(id)
+}
+
+// create appends a link. The caller must hold the write lock.
+func (s *MemoryStore) create(url string) Link {
+	link := Link{
+		ID:        int64(len(s.links)) + 1,
+		URL:       url,
+		CreatedAt: s.now().UTC(),
+	}
+	s.links = append(s.links, link)
+	return link
+}
+
+// hit increments a counter. The caller must hold the write lock.
+func (s *MemoryStore) hit(id int64) (Link, error) {
+	i, ok := s.index(id)
+	if !ok {
+		return Link{}, ErrNotFound
+	}
+	s.links[i].Hits++
+	return s.links[i], nil
+}
+
+// index returns the slice position of id.
+func (s *MemoryStore) index(id int64) (int, bool) {
+	if id < 1 || id > int64(len(s.links)) {
+		return 0, false
+	}
+	return int(id - 1), true
+}
+
+// FileStore is a Store backed by a JSON Lines file, which suits small
+// deployments and demos. Every change appends one line with the updated
+// link, so a hit costs one short write rather than a rewrite of the file.
+// NewFileStore replays the file, keeping the last line of each link, and
+// compacts it to one line per link.
+type FileStore struct {
+	mem  *MemoryStore
+	path string
+}
+
+// NewFileStore opens the store at path, loading existing links if the file
+// exists. A final line cut short by a crash is discarded.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{mem: NewMemoryStore(), path: path}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("shortener: read store: %w", err)
+	}
+
+	torn := false
+	if i := bytes.LastIndexByte(data, '\n'); i < len(data)-1 {
+		data, torn = data[:i+1], true
+	}
+	records := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var link Link
+		if err := json.Unmarshal(line, &link); err != nil {
+			return nil, fmt.Errorf("shortener: decode store: %w", err)
+		}
+		switch i, ok := s.mem.index(link.ID); {
+		case ok:
+			s.mem.links[i] = link
+		case link.ID == int64(len(s.mem.links))+1:
+			s.mem.links = append(s.mem.links, link)
+		default:
+			return nil, fmt.Errorf("shortener: decode store: unexpected link ID %d", link.ID)
+		}
+		records++
+	}
+	if torn || records != len(s.mem.links) {
+		if err := s.Compact(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Create stores url under the next sequential ID and appends it to the file.
+func (s *FileStore) Create(_ context.Context, url string) (Link, error) {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+	link := s.mem.create(url)
+	if err := s.append(link); err != nil {
+		s.mem.links = s.mem.links[:len(s.mem.links)-1]
+		return Link{}, err
+	}
+	return link, nil
+}
+
+// Get returns the link with the given ID, or ErrNotFound.
+func (s *FileStore) Get(ctx context.Context, id int64) (Link, error) {
+	return s.mem.Get(ctx, id)
+}
+
+// Hit increments the hit counter of the link with the given ID and appends
+// the updated link to the file.
+func (s *FileStore) Hit(_ context.Context, id int64) (Link, error) {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+	link, err := s.mem.hit(id)
+	if err != nil {
+		return Link{}, err
+	}
+	if err := s.append(link); err != nil {
+		s.mem.links[link.ID-1].Hits--
+		return Link{}, err
+	}
+	return link, nil
+}
+
+// Compact rewrites the file with one line per link, replacing it atomically
+// through a temporary file.
+func (s *FileStore) Compact() error {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, link := range s.mem.links {
+		if err := enc.Encode(link); err != nil {
+			return fmt.Errorf("shortener: encode store: %w", err)
+		}
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("shortener: write store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("shortener: write store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("shortener: write store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("shortener: write store: %w", err)
+	}
+	return nil
+}
+
+// append writes link as one line at the end of the file.
+// The caller must hold the write lock.
+func (s *FileStore) append(link Link) error {
+	line, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("shortener: encode store: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("shortener: write store: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("shortener: write store: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("shortener: write store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package shortener_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wow-apps/youtube-id-go/shortener"
+)
+
+// testStore exercises the Store contract.
+func testStore(t *testing.T, store shortener.Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	first, err := store.Create(ctx, "https://example.com/a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := store.Create(ctx, "https://example.com/b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.ID != 1 || second.ID != 2 {
+		t.Errorf("expected sequential IDs 1 and 2, got %d and %d", first.ID, second.ID)
+	}
+	if first.CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be set")
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := store.Hit(ctx, second.ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	got, err := store.Get(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.URL != "https://example.com/b" || got.Hits != 3 {
+		t.Errorf("unexpected link: %+v", got)
+	}
+
+	for _, id := range []int64{0, -1, 3} {
+		if _, err := store.Get(ctx, id); !errors.Is(err, shortener.ErrNotFound) {
+			t.Errorf("Get(%d): expected ErrNotFound, got %v", id, err)
+		}
+		if _, err := store.Hit(ctx, id); !errors.Is(err, shortener.ErrNotFound) {
+			t.Errorf("Hit(%d): expected ErrNotFound, got %v", id, err)
+		}
+	}
+}
+
+// TestMemoryStore tests the in-memory store.
+func TestMemoryStore(t *testing.T) {
+	testStore(t, shortener.NewMemoryStore())
+}
+
+// TestFileStore tests the file-backed store and that it reloads saved links.
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.jsonl")
+	store, err := shortener.NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testStore(t, store)
+
+	reopened, err := shortener.NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	link, err := reopened.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link.Hits != 3 {
+		t.Errorf("expected 3 hits after reload, got %d", link.Hits)
+	}
+	next, err := reopened.Create(context.Background(), "https://example.com/c")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.ID != 3 {
+		t.Errorf("expected ID 3 after reload, got %d", next.ID)
+	}
+}
+
+// TestFileStore_Appends tests that hits append to the file instead of rewriting it,
+// and that reopening compacts the file to one line per link.
+func TestFileStore_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.jsonl")
+	store, err := shortener.NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := store.Create(ctx, "https://example.com/a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before := readFile(t, path)
+	for i := 0; i < 2; i++ {
+		if _, err := store.Hit(ctx, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	after := readFile(t, path)
+	if !strings.HasPrefix(after, before) || strings.Count(after, "\n") != 3 {
+		t.Errorf("expected hits to be appended to %q, got %q", before, after)
+	}
+
+	if _, err := shortener.NewFileStore(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if compacted := readFile(t, path); strings.Count(compacted, "\n") != 1 || !strings.Contains(compacted, `"hits":2`) {
+		t.Errorf("expected one line with 2 hits after reopening, got %q", compacted)
+	}
+}
+
+// TestFileStore_TornWrite tests that a final line cut short by a crash is discarded.
+func TestFileStore_TornWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.jsonl")
+	store, err := shortener.NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Create(context.Background(), "https://example.com/a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	complete := readFile(t, path)
+	if err := os.WriteFile(path, []byte(complete+`{"id":2,"url":"ht`), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reopened, err := shortener.NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := readFile(t, path); got != complete {
+		t.Errorf("expected torn line to be dropped, got %q", got)
+	}
+	next, err := reopened.Create(context.Background(), "https://example.com/b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.ID != 2 {
+		t.Errorf("expected ID 2, got %d", next.ID)
+	}
+}
+
+// TestFileStore_Corrupt tests that an unreadable store file is reported.
+func TestFileStore_Corrupt(t *testing.T) {
+	for _, content := range []string{"{\n", `{"id":2}` + "\n"} {
+		path := filepath.Join(t.TempDir(), "links.jsonl")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := shortener.NewFileStore(path); err == nil {
+			t.Errorf("expected error for corrupt store %q", content)
+		}
+	}
+	if _, err := shortener.NewFileStore(t.TempDir()); err == nil {
+		t.Error("expected error for directory path")
+	}
+}
+
+// TestFileStore_SaveError tests that failed writes are reported and rolled back.
+func TestFileStore_SaveError(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "store")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store, err := shortener.NewFileStore(filepath.Join(dir, "links.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := store.Create(ctx, "https://example.com/a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Create(ctx, "https://example.com/b"); err == nil {
+		t.Error("expected error from Create")
+	}
+	if _, err := store.Hit(ctx, 1); err == nil {
+		t.Error("expected error from Hit")
+	}
+	link, err := store.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link.Hits != 0 {
+		t.Errorf("expected hit to be rolled back, got %d", link.Hits)
+	}
+	if _, err := store.Get(ctx, 2); !errors.Is(err, shortener.ErrNotFound) {
+		t.Errorf("expected failed create to be rolled back, got %v", err)
+	}
+}
+
+// readFile returns the contents of path.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(data)
+}
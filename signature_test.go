@@ -0,0 +1,215 @@
+package yid_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+var signKey = []byte("hmac-secret")
+
+// TestSignature_Roundtrip tests that signed IDs decode back to their number.
+func TestSignature_Roundtrip(t *testing.T) {
+	encoders := map[string]*yid.Encoder{
+		"default":  yid.New(yid.WithSignature(signKey, 6)),
+		"secure":   yid.New(yid.WithSignature(signKey, 6), yid.WithSecureKey("secret")),
+		"padUp":    yid.New(yid.WithSignature(signKey, 4), yid.WithPadUp(3)),
+		"sortable": yid.New(yid.WithSignature(signKey, 6), yid.WithSortable()),
+	}
+	for name, enc := range encoders {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int64{0, 1, 61, 62, 12345, 1 << 50} {
+				encoded, err := enc.EncodeRaw(n)
+				if err != nil {
+					t.Fatalf("EncodeRaw(%d) error: %v", n, err)
+				}
+				decoded, err := enc.Decode(encoded)
+				if err != nil {
+					t.Fatalf("Decode(%q) error: %v", encoded, err)
+				}
+				if decoded != n {
+					t.Errorf("roundtrip failed: %d -> %q -> %d", n, encoded, decoded)
+				}
+			}
+		})
+	}
+}
+
+// TestSignature_AppendsChars tests that the unsigned encoding is kept as a prefix.
+func TestSignature_AppendsChars(t *testing.T) {
+	encoded, err := yid.ToAlphanumeric(12345, yid.WithSignature(signKey, 6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(encoded) != 9 || !strings.HasPrefix(encoded, "dnh") {
+		t.Errorf("expected \"dnh\" plus 6 characters, got %q", encoded)
+	}
+}
+
+// TestSignature_Tampered tests that guessed, edited and truncated IDs are rejected.
+func TestSignature_Tampered(t *testing.T) {
+	enc := yid.New(yid.WithSignature(signKey, 6))
+	valid, _ := enc.EncodeRaw(12345)
+	other, _ := enc.EncodeRaw(12346)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unsigned", "dnh"},
+		{"empty", ""},
+		{"signature only", valid[3:]},
+		{"edited number", "dni" + valid[3:]},
+		{"edited signature", valid[:8] + flip(valid[8])},
+		{"swapped signature", valid[:3] + other[3:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := enc.Decode(tt.input); !errors.Is(err, yid.ErrInvalidSignature) {
+				t.Errorf("Decode(%q): expected ErrInvalidSignature, got %v", tt.input, err)
+			}
+		})
+	}
+}
+
+// TestSignature_WrongKey tests that IDs signed with another key are rejected.
+func TestSignature_WrongKey(t *testing.T) {
+	encoded, _ := yid.ToAlphanumeric(12345, yid.WithSignature(signKey, 6))
+	_, err := yid.ToNumeric(encoded, yid.WithSignature([]byte("other"), 6))
+	if !errors.Is(err, yid.ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+// TestSignature_LeadingZeros tests that a signed ID has exactly one valid
+// form: a leading zero character would otherwise decode to the same number
+// under the same signature.
+func TestSignature_LeadingZeros(t *testing.T) {
+	enc := yid.New(yid.WithSignature(signKey, 6))
+	valid, _ := enc.EncodeRaw(12345)
+	for _, input := range []string{"a" + valid, "aa" + valid} {
+		if _, err := enc.Decode(input); !errors.Is(err, yid.ErrNotCanonical) {
+			t.Errorf("Decode(%q): expected ErrNotCanonical, got %v", input, err)
+		}
+		if err := enc.Validate(input); !errors.Is(err, yid.ErrNotCanonical) {
+			t.Errorf("Validate(%q): expected ErrNotCanonical, got %v", input, err)
+		}
+	}
+	zero, _ := enc.EncodeRaw(0)
+	if n, err := enc.Decode(zero); err != nil || n != 0 {
+		t.Errorf("expected 0, got %d, %v", n, err)
+	}
+}
+
+// TestSignature_BoundToEncoder tests that a signature issued by one encoder
+// is rejected by another with the same key but a different prefix or
+// dictionary.
+func TestSignature_BoundToEncoder(t *testing.T) {
+	users := yid.New(yid.WithPrefix("usr_"), yid.WithSignature(signKey, 6))
+	orgs := yid.New(yid.WithPrefix("org_"), yid.WithSignature(signKey, 6))
+	id, _ := users.Encode(12345)
+	if _, err := orgs.Decode("org_" + strings.TrimPrefix(id, "usr_")); !errors.Is(err, yid.ErrInvalidSignature) {
+		t.Errorf("expected a user ID with the org prefix to be rejected, got %v", err)
+	}
+
+	// Rewrite the signature digit by digit into the shuffled dictionary.
+	plain := yid.New(yid.WithSignature(signKey, 6))
+	keyed := yid.New(yid.WithSecureKey("secret"), yid.WithSignature(signKey, 6))
+	id, _ = plain.Encode(12345)
+	var sig strings.Builder
+	for _, c := range id[len(id)-6:] {
+		d, _ := yid.ToNumeric(string(c))
+		digit, _ := yid.ToAlphanumeric(d, yid.WithSecureKey("secret"))
+		sig.WriteString(digit)
+	}
+	payload, _ := yid.ToAlphanumeric(12345, yid.WithSecureKey("secret"))
+	if _, err := keyed.Decode(payload + sig.String()); !errors.Is(err, yid.ErrInvalidSignature) {
+		t.Errorf("expected a signature from another dictionary to be rejected, got %v", err)
+	}
+}
+
+// TestSignature_InvalidCharacter tests that character errors take precedence.
+func TestSignature_InvalidCharacter(t *testing.T) {
+	_, err := yid.ToNumeric("dn-abcdef", yid.WithSignature(signKey, 6))
+	if !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter, got %v", err)
+	}
+}
+
+// TestSignature_Clamp tests that nChars is clamped to at least 1 and to the
+// digits of the alphabet that fit in 64 bits.
+func TestSignature_Clamp(t *testing.T) {
+	tests := []struct {
+		alphabet string
+		nChars   int
+		expected int
+	}{
+		{yid.DefaultAlphabet, -1, 1},
+		{yid.DefaultAlphabet, 0, 1},
+		{yid.DefaultAlphabet, 1, 1},
+		{yid.DefaultAlphabet, yid.MaxSignatureChars, yid.MaxSignatureChars},
+		{yid.DefaultAlphabet, yid.MaxSignatureChars + 5, yid.MaxSignatureChars},
+		{yid.CrockfordAlphabet, 20, 12},
+		{"0123456789", 20, 19},
+		{"01", 40, 40},
+		{"01", 100, 64},
+	}
+	for _, tt := range tests {
+		unsigned, err := yid.New(yid.WithAlphabet(tt.alphabet)).EncodeRaw(12345)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		signed, err := yid.New(yid.WithAlphabet(tt.alphabet), yid.WithSignature(signKey, tt.nChars)).EncodeRaw(12345)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := len(signed) - len(unsigned); got != tt.expected {
+			t.Errorf("%d-character alphabet, WithSignature(key, %d): expected %d signature characters, got %d",
+				len(tt.alphabet), tt.nChars, tt.expected, got)
+		}
+	}
+}
+
+// TestSignature_EmptyKey tests that an empty key disables signing.
+func TestSignature_EmptyKey(t *testing.T) {
+	encoded, _ := yid.ToAlphanumeric(12345, yid.WithSignature(nil, 6))
+	if encoded != "dnh" {
+		t.Errorf("expected dnh, got %q", encoded)
+	}
+}
+
+// TestSignature_KeyCopied tests that later changes to the key slice have no effect.
+func TestSignature_KeyCopied(t *testing.T) {
+	key := []byte("hmac-secret")
+	opt := yid.WithSignature(key, 6)
+	key[0] = 'X'
+	a, _ := yid.ToAlphanumeric(12345, opt)
+	b, _ := yid.ToAlphanumeric(12345, yid.WithSignature(signKey, 6))
+	if a != b {
+		t.Errorf("expected %q, got %q", b, a)
+	}
+}
+
+// TestSignature_SortablePreservesOrder tests that signatures do not break ordering.
+func TestSignature_SortablePreservesOrder(t *testing.T) {
+	enc := yid.New(yid.WithSignature(signKey, 6), yid.WithSortable())
+	prev, _ := enc.EncodeRaw(0)
+	for _, n := range []int64{1, 61, 62, 3843, 3844, math.MaxInt64} {
+		encoded, _ := enc.EncodeRaw(n)
+		if encoded <= prev {
+			t.Errorf("expected %q > %q", encoded, prev)
+		}
+		prev = encoded
+	}
+}
+
+// flip returns a different dictionary character than c.
+func flip(c byte) string {
+	if c == 'a' {
+		return "b"
+	}
+	return "a"
+}
@@ -0,0 +1,99 @@
+// Package slogx rewrites integer ID attributes into their yid form in
+// log/slog output.
+//
+// Example:
+//
+//	enc := yid.New(yid.WithSecureKey("my-secret"))
+//	logger := slog.New(slogx.NewHandler(slog.NewJSONHandler(os.Stderr, nil), enc, "user_id", "order_id"))
+//	logger.Info("order placed", "user_id", 12345, "order_id", 67890)
+//	// -> {"msg":"order placed","user_id":"hqj","order_id":"..."}
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"math"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// Handler is a slog.Handler that encodes the values of configured attribute
+// keys before passing records to the next handler. Keys are matched at any
+// group depth. Only non-negative integer values are rewritten; other values,
+// and values the encoder rejects, are passed through unchanged.
+type Handler struct {
+	next slog.Handler
+	enc  *yid.Encoder
+	keys map[string]bool
+}
+
+// NewHandler returns a Handler that encodes the attributes named by keys
+// with enc and passes records on to next.
+func NewHandler(next slog.Handler, enc *yid.Encoder, keys ...string) *Handler {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return &Handler{next: next, enc: enc, keys: set}
+}
+
+// Enabled implements slog.Handler.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.rewrite(a))
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	rewritten := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		rewritten[i] = h.rewrite(a)
+	}
+	return &Handler{next: h.next.WithAttrs(rewritten), enc: h.enc, keys: h.keys}
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), enc: h.enc, keys: h.keys}
+}
+
+// rewrite encodes a if its key is configured, recursing into groups.
+func (h *Handler) rewrite(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		rewritten := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			rewritten[i] = h.rewrite(ga)
+		}
+		a.Value = slog.GroupValue(rewritten...)
+	case slog.KindInt64:
+		if h.keys[a.Key] {
+			a.Value = h.encode(a.Value.Int64(), a.Value)
+		}
+	case slog.KindUint64:
+		if h.keys[a.Key] && a.Value.Uint64() <= math.MaxInt64 {
+			a.Value = h.encode(int64(a.Value.Uint64()), a.Value)
+		}
+	}
+	return a
+}
+
+// encode returns the encoded form of n, or fallback if it cannot be encoded.
+func (h *Handler) encode(n int64, fallback slog.Value) slog.Value {
+	s, err := h.enc.Encode(n)
+	if err != nil {
+		return fallback
+	}
+	return slog.StringValue(s)
+}
@@ -0,0 +1,92 @@
+package slogx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/slogx"
+)
+
+// logJSON logs through a Handler and returns the decoded JSON line.
+func logJSON(t *testing.T, fn func(*slog.Logger)) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	h := slogx.NewHandler(slog.NewJSONHandler(&buf, nil), yid.New(), "user_id", "order_id")
+	fn(slog.New(h))
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	return out
+}
+
+// TestHandler_RewritesConfiguredKeys tests that only configured integer attributes are encoded.
+func TestHandler_RewritesConfiguredKeys(t *testing.T) {
+	out := logJSON(t, func(l *slog.Logger) {
+		l.Info("order placed", "user_id", 12345, "order_id", uint64(12345), "count", 12345, "name", "user_id")
+	})
+	tests := map[string]any{
+		"user_id":  "dnh",
+		"order_id": "dnh",
+		"count":    float64(12345),
+		"name":     "user_id",
+	}
+	for key, expected := range tests {
+		if out[key] != expected {
+			t.Errorf("%s: expected %v, got %v", key, expected, out[key])
+		}
+	}
+}
+
+// TestHandler_Unencodable tests that values the encoder rejects pass through.
+func TestHandler_Unencodable(t *testing.T) {
+	out := logJSON(t, func(l *slog.Logger) {
+		l.Info("odd", "user_id", -1, "order_id", "abc")
+	})
+	if out["user_id"] != float64(-1) || out["order_id"] != "abc" {
+		t.Errorf("expected values unchanged, got %v and %v", out["user_id"], out["order_id"])
+	}
+}
+
+// TestHandler_WithAttrsAndGroups tests rewriting of attributes added with With, groups and LogValuers.
+func TestHandler_WithAttrsAndGroups(t *testing.T) {
+	out := logJSON(t, func(l *slog.Logger) {
+		l.With("user_id", 12345).WithGroup("req").Info("hit",
+			slog.Group("order", "order_id", 12345),
+			"lazy", slog.AnyValue(idValuer(12345)))
+	})
+	if out["user_id"] != "dnh" {
+		t.Errorf("expected With attribute to be encoded, got %v", out["user_id"])
+	}
+	req, _ := out["req"].(map[string]any)
+	order, _ := req["order"].(map[string]any)
+	if order["order_id"] != "dnh" {
+		t.Errorf("expected grouped attribute to be encoded, got %v", out)
+	}
+	lazy, _ := req["lazy"].(map[string]any)
+	if lazy["user_id"] != "dnh" {
+		t.Errorf("expected LogValuer attribute to be encoded, got %v", out)
+	}
+}
+
+// TestHandler_Enabled tests that levels are delegated.
+func TestHandler_Enabled(t *testing.T) {
+	var buf bytes.Buffer
+	next := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+	l := slog.New(slogx.NewHandler(next, yid.New(), "user_id"))
+	l.Info("dropped", "user_id", 1)
+	if buf.Len() != 0 {
+		t.Errorf("expected info record to be dropped, got %q", buf.String())
+	}
+}
+
+// idValuer logs as a group containing a user_id.
+type idValuer int64
+
+func (v idValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int64("user_id", int64(v)))
+}
@@ -0,0 +1,225 @@
+// Package sqlx wraps database/sql so that yid.Encoded query parameters are
+// decoded and *yid.Encoded scan destinations are encoded automatically.
+//
+// Parameters of type yid.Encoded, including the values of sql.NamedArg, are
+// decoded with the configured Encoder before the query reaches the driver;
+// a string that does not decode fails the call without touching the
+// database. Integer columns scanned into a *yid.Encoded are encoded with
+// EncodeRaw, so that scanned IDs decode back to the same row even with a
+// case transformation or grouping.
+//
+// Example:
+//
+//	db := sqlx.Wrap(sqlDB, yid.New(yid.WithSecureKey("my-secret")))
+//	var (
+//		id   yid.Encoded
+//		name string
+//	)
+//	err := db.QueryRowContext(ctx, "SELECT id, name FROM users WHERE id = ?",
+//		yid.Encoded(r.PathValue("id"))).Scan(&id, &name)
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// queryer is the part of the *sql.DB and *sql.Tx API wrapped by conn.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn implements the query methods shared by DB and Tx.
+type conn struct {
+	q   queryer
+	enc *yid.Encoder
+}
+
+// DB wraps a *sql.DB. It is safe for concurrent use.
+type DB struct {
+	conn
+	db *sql.DB
+}
+
+// Wrap returns a DB that decodes and encodes IDs with enc.
+func Wrap(db *sql.DB, enc *yid.Encoder) *DB {
+	return &DB{conn: conn{q: db, enc: enc}, db: db}
+}
+
+// DB returns the wrapped *sql.DB.
+func (db *DB) DB() *sql.DB {
+	return db.db
+}
+
+// BeginTx starts a transaction whose queries are wrapped like the DB's.
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{conn: conn{q: tx, enc: db.enc}, tx: tx}, nil
+}
+
+// Tx wraps a *sql.Tx.
+type Tx struct {
+	conn
+	tx *sql.Tx
+}
+
+// Tx returns the wrapped *sql.Tx.
+func (tx *Tx) Tx() *sql.Tx {
+	return tx.tx
+}
+
+// Commit commits the transaction.
+func (tx *Tx) Commit() error {
+	return tx.tx.Commit()
+}
+
+// Rollback aborts the transaction.
+func (tx *Tx) Rollback() error {
+	return tx.tx.Rollback()
+}
+
+// ExecContext decodes args and executes a query that returns no rows.
+func (c *conn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	args, err := c.decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return c.q.ExecContext(ctx, query, args...)
+}
+
+// Exec is ExecContext with context.Background.
+func (c *conn) Exec(query string, args ...any) (sql.Result, error) {
+	return c.ExecContext(context.Background(), query, args...)
+}
+
+// QueryContext decodes args and executes a query that returns rows.
+func (c *conn) QueryContext(ctx context.Context, query string, args ...any) (*Rows, error) {
+	args, err := c.decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &Rows{Rows: rows, enc: c.enc}, nil
+}
+
+// Query is QueryContext with context.Background.
+func (c *conn) Query(query string, args ...any) (*Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+// QueryRowContext decodes args and executes a query that returns at most one
+// row. Errors, including decode errors, are deferred until Row.Scan.
+func (c *conn) QueryRowContext(ctx context.Context, query string, args ...any) *Row {
+	args, err := c.decodeArgs(args)
+	if err != nil {
+		return &Row{err: err}
+	}
+	return &Row{row: c.q.QueryRowContext(ctx, query, args...), enc: c.enc}
+}
+
+// QueryRow is QueryRowContext with context.Background.
+func (c *conn) QueryRow(query string, args ...any) *Row {
+	return c.QueryRowContext(context.Background(), query, args...)
+}
+
+// decodeArgs returns a copy of args with yid.Encoded values decoded.
+func (c *conn) decodeArgs(args []any) ([]any, error) {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case yid.Encoded:
+			n, err := c.enc.Decode(string(v))
+			if err != nil {
+				return nil, fmt.Errorf("sqlx: argument %d: %w", i+1, err)
+			}
+			out[i] = n
+		case sql.NamedArg:
+			if s, ok := v.Value.(yid.Encoded); ok {
+				n, err := c.enc.Decode(string(s))
+				if err != nil {
+					return nil, fmt.Errorf("sqlx: argument %q: %w", v.Name, err)
+				}
+				v.Value = n
+			}
+			out[i] = v
+		default:
+			out[i] = arg
+		}
+	}
+	return out, nil
+}
+
+// Rows wraps *sql.Rows; Scan encodes into *yid.Encoded destinations.
+type Rows struct {
+	*sql.Rows
+	enc *yid.Encoder
+}
+
+// Scan copies the columns of the current row into dest, like sql.Rows.Scan.
+// Integer columns scanned into a *yid.Encoded are encoded with EncodeRaw.
+func (r *Rows) Scan(dest ...any) error {
+	return scan(r.enc, r.Rows.Scan, dest)
+}
+
+// Row wraps *sql.Row; Scan encodes into *yid.Encoded destinations.
+type Row struct {
+	row *sql.Row
+	enc *yid.Encoder
+	err error
+}
+
+// Scan copies the columns of the row into dest, like sql.Row.Scan.
+// Integer columns scanned into a *yid.Encoded are encoded with EncodeRaw.
+func (r *Row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scan(r.enc, r.row.Scan, dest)
+}
+
+// Err returns the error of the query, if any, without scanning.
+func (r *Row) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Err()
+}
+
+// scan calls fn with *yid.Encoded destinations replaced by *int64 and
+// encodes the scanned numbers into them. EncodeRaw is used because
+// transformed or grouped output does not decode back to the same number.
+func scan(enc *yid.Encoder, fn func(dest ...any) error, dest []any) error {
+	targets := make([]any, len(dest))
+	numbers := make(map[int]*int64)
+	for i, d := range dest {
+		if _, ok := d.(*yid.Encoded); ok {
+			n := new(int64)
+			numbers[i] = n
+			targets[i] = n
+			continue
+		}
+		targets[i] = d
+	}
+	if err := fn(targets...); err != nil {
+		return err
+	}
+	for i, n := range numbers {
+		s, err := enc.EncodeRaw(*n)
+		if err != nil {
+			return fmt.Errorf("sqlx: column %d: %w", i+1, err)
+		}
+		*dest[i].(*yid.Encoded) = yid.Encoded(s)
+	}
+	return nil
+}
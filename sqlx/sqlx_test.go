@@ -0,0 +1,269 @@
+package sqlx_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+	"github.com/wow-apps/youtube-id-go/sqlx"
+)
+
+// fakeDriver is an in-process driver over a single users table.
+// "SELECT" queries return the user whose ID is the first argument; any
+// other query records its arguments.
+type fakeDriver struct {
+	mu    sync.Mutex
+	users map[int64]string
+	args  []driver.NamedValue
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c.d, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("use ExecContext")
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("use QueryContext")
+}
+
+func (s *fakeStmt) ExecContext(_ context.Context, args []driver.NamedValue) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.args = args
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) QueryContext(_ context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.args = args
+	rows := &fakeRows{}
+	if s.query == "SELECT" && len(args) > 0 {
+		if id, ok := args[0].Value.(int64); ok {
+			if name, ok := s.d.users[id]; ok {
+				rows.data = [][]driver.Value{{id, name}}
+			}
+		}
+	}
+	if s.query == "NEGATIVE" {
+		rows.data = [][]driver.Value{{int64(-1), "broken"}}
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	data [][]driver.Value
+	pos  int
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id", "name"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.pos])
+	r.pos++
+	return nil
+}
+
+var (
+	registerOnce sync.Once
+	fake         = &fakeDriver{users: map[int64]string{12345: "alice"}}
+)
+
+func openDB(t *testing.T, opts ...yid.Option) (*sqlx.DB, *yid.Encoder) {
+	t.Helper()
+	registerOnce.Do(func() { sql.Register("yidfake", fake) })
+	db, err := sql.Open("yidfake", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	enc := yid.New(append([]yid.Option{yid.WithSecureKey("secret")}, opts...)...)
+	return sqlx.Wrap(db, enc), enc
+}
+
+// lastArgs returns the arguments of the last statement the driver saw.
+func lastArgs() []driver.NamedValue {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	return fake.args
+}
+
+// TestQueryRow_DecodesAndEncodes tests a round trip through the driver.
+func TestQueryRow_DecodesAndEncodes(t *testing.T) {
+	db, enc := openDB(t)
+	encoded, _ := enc.Encode(12345)
+
+	var (
+		id   yid.Encoded
+		name string
+	)
+	if err := db.QueryRow("SELECT", yid.Encoded(encoded)).Scan(&id, &name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(id) != encoded || name != "alice" {
+		t.Errorf("expected (%s, alice), got (%s, %s)", encoded, id, name)
+	}
+	if args := lastArgs(); args[0].Value != int64(12345) {
+		t.Errorf("expected driver to receive 12345, got %v", args[0].Value)
+	}
+}
+
+// TestScan_RoundTripsWithTransform tests that a scanned ID decodes back to
+// the same number when the encoder transforms its display output.
+func TestScan_RoundTripsWithTransform(t *testing.T) {
+	db, enc := openDB(t, yid.WithTransform(yid.TransformUpper), yid.WithGrouping(2, "-"))
+	raw, _ := enc.EncodeRaw(12345)
+
+	var (
+		id   yid.Encoded
+		name string
+	)
+	if err := db.QueryRow("SELECT", yid.Encoded(raw)).Scan(&id, &name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(id) != raw {
+		t.Errorf("expected raw %s, got %s", raw, id)
+	}
+	n, err := enc.Decode(string(id))
+	if err != nil || n != 12345 {
+		t.Errorf("expected scanned ID to decode to 12345, got %d, %v", n, err)
+	}
+	if err := db.QueryRow("SELECT", id).Scan(&id, &name); err != nil || name != "alice" {
+		t.Errorf("expected scanned ID to find the row again, got %q, %v", name, err)
+	}
+}
+
+// TestQuery_ScanRows tests Rows.Scan and plain integer destinations.
+func TestQuery_ScanRows(t *testing.T) {
+	db, enc := openDB(t)
+	encoded, _ := enc.Encode(12345)
+
+	rows, err := db.QueryContext(context.Background(), "SELECT", yid.Encoded(encoded))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	var (
+		id   int64
+		name string
+	)
+	if err := rows.Scan(&id, &name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 12345 {
+		t.Errorf("expected raw 12345 for *int64 destination, got %d", id)
+	}
+}
+
+// TestExec_NamedArgs tests that named yid.Encoded arguments are decoded.
+func TestExec_NamedArgs(t *testing.T) {
+	db, enc := openDB(t)
+	encoded, _ := enc.Encode(42)
+
+	_, err := db.Exec("UPDATE", sql.Named("id", yid.Encoded(encoded)), sql.Named("name", "bob"), "plain")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := lastArgs()
+	if args[0].Name != "id" || args[0].Value != int64(42) {
+		t.Errorf("expected id=42, got %s=%v", args[0].Name, args[0].Value)
+	}
+	if args[1].Value != "bob" || args[2].Value != "plain" {
+		t.Errorf("expected other arguments unchanged, got %v", args)
+	}
+}
+
+// TestInvalidArgument tests that invalid IDs fail before reaching the driver.
+func TestInvalidArgument(t *testing.T) {
+	db, _ := openDB(t)
+	db.Exec("RESET")
+
+	if _, err := db.Exec("UPDATE", yid.Encoded("a-b")); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("Exec: expected ErrInvalidCharacter, got %v", err)
+	}
+	if _, err := db.Query("SELECT", sql.Named("id", yid.Encoded("a-b"))); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("Query: expected ErrInvalidCharacter, got %v", err)
+	}
+	row := db.QueryRow("SELECT", yid.Encoded("a-b"))
+	if err := row.Err(); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("Row.Err: expected ErrInvalidCharacter, got %v", err)
+	}
+	var id yid.Encoded
+	if err := row.Scan(&id); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("Row.Scan: expected ErrInvalidCharacter, got %v", err)
+	}
+	if len(lastArgs()) != 0 {
+		t.Errorf("expected driver not to see invalid queries, got %v", lastArgs())
+	}
+}
+
+// TestScan_Errors tests that missing rows and unencodable values are reported.
+func TestScan_Errors(t *testing.T) {
+	db, enc := openDB(t)
+	missing, _ := enc.Encode(99)
+
+	var id yid.Encoded
+	var name string
+	if err := db.QueryRow("SELECT", yid.Encoded(missing)).Scan(&id, &name); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+	if err := db.QueryRow("NEGATIVE").Scan(&id, &name); !errors.Is(err, yid.ErrNegativeNumber) {
+		t.Errorf("expected ErrNegativeNumber, got %v", err)
+	}
+}
+
+// TestTx tests that transactions are wrapped too.
+func TestTx(t *testing.T) {
+	db, enc := openDB(t)
+	encoded, _ := enc.Encode(7)
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tx.Exec("UPDATE", yid.Encoded(encoded)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args := lastArgs(); args[0].Value != int64(7) {
+		t.Errorf("expected 7, got %v", args[0].Value)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.Tx() == nil || db.DB() == nil {
+		t.Error("expected wrapped values to be exposed")
+	}
+}
@@ -0,0 +1,291 @@
+package yid
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// BlockSize is the number of bytes the streaming encoder turns into one
+// fixed-width group of dictionary characters. With the default alphabet a
+// full block takes 43 characters; a shorter final block takes the fewest
+// characters that can hold its length, which identifies it when decoding.
+const BlockSize = 32
+
+// errStreamClosed is returned by writes to a closed streaming encoder.
+var errStreamClosed = errors.New("yid: write to closed streaming encoder")
+
+// streamChunk is the number of characters the streaming decoder reads at a time.
+const streamChunk = 4096
+
+// blockWidths returns the number of characters for blocks of 0 through
+// BlockSize bytes: the smallest k with base^k >= 256^n.
+func blockWidths(base int) [BlockSize + 1]int {
+	var widths [BlockSize + 1]int
+	b := big.NewInt(int64(base))
+	limit, pow := big.NewInt(1), big.NewInt(1)
+	k := 0
+	for n := 1; n <= BlockSize; n++ {
+		limit.Lsh(limit, 8)
+		for pow.Cmp(limit) < 0 {
+			pow.Mul(pow, b)
+			k++
+		}
+		widths[n] = k
+	}
+	return widths
+}
+
+// NewStreamingEncoder returns a writer that encodes the bytes written to it
+// with e's dictionary and writes the characters to w. Each BlockSize bytes
+// become a fixed-width group, so memory use does not depend on the input
+// length. Close must be called to flush the final partial block; it does
+// not close w. The prefix, signature, grouping and case transformation of
+// e are not applied.
+//
+// Example:
+//
+//	w := enc.NewStreamingEncoder(&buf)
+//	io.Copy(w, file)
+//	w.Close()
+func (e *Encoder) NewStreamingEncoder(w io.Writer) io.WriteCloser {
+	return &streamEncoder{enc: e, w: w, widths: blockWidths(len(e.dictionary)), err: e.err}
+}
+
+// NewStreamingDecoder returns a reader that decodes the characters read
+// from r, as written by NewStreamingEncoder with the same dictionary and
+// key. Line breaks are ignored, and input is normalized for
+// case-insensitive alphabets and WithLookalikes. Reads fail with
+// ErrInvalidCharacter for characters outside the dictionary, ErrOverflow
+// for groups that hold no valid block and ErrInvalidLength if the input ends
+// with a group of a length no block is encoded to.
+func (e *Encoder) NewStreamingDecoder(r io.Reader) io.Reader {
+	return &streamDecoder{enc: e, r: r, widths: blockWidths(len(e.dictionary)), err: e.err}
+}
+
+// NewStreamingEncoder returns a streaming encoder for the given options.
+// See Encoder.NewStreamingEncoder.
+//
+// Example:
+//
+//	w := yid.NewStreamingEncoder(os.Stdout, yid.WithSecureKey("secret"))
+//	w.Write(cursor)
+//	w.Close()
+func NewStreamingEncoder(w io.Writer, opts ...Option) io.WriteCloser {
+	return cachedEncoder(opts).NewStreamingEncoder(w)
+}
+
+// NewStreamingDecoder returns a streaming decoder for the given options.
+// See Encoder.NewStreamingDecoder.
+//
+// Example:
+//
+//	cursor, err := io.ReadAll(yid.NewStreamingDecoder(strings.NewReader(s), yid.WithSecureKey("secret")))
+func NewStreamingDecoder(r io.Reader, opts ...Option) io.Reader {
+	return cachedEncoder(opts).NewStreamingDecoder(r)
+}
+
+// streamEncoder implements Encoder.NewStreamingEncoder.
+type streamEncoder struct {
+	enc    *Encoder
+	w      io.Writer
+	widths [BlockSize + 1]int
+	block  [BlockSize]byte
+	n      int
+	out    []byte
+	err    error
+}
+
+// Write buffers p and writes every completed block.
+func (s *streamEncoder) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	written := 0
+	for len(p) > 0 {
+		c := copy(s.block[s.n:], p)
+		s.n += c
+		p = p[c:]
+		if s.n == BlockSize {
+			if s.err = s.flush(); s.err != nil {
+				return written, s.err
+			}
+		}
+		written += c
+	}
+	return written, nil
+}
+
+// Close writes the final partial block, if any. Later writes fail.
+func (s *streamEncoder) Close() error {
+	if s.err != nil {
+		if s.err == errStreamClosed {
+			return nil
+		}
+		return s.err
+	}
+	if s.n > 0 {
+		if err := s.flush(); err != nil {
+			s.err = err
+			return err
+		}
+	}
+	s.err = errStreamClosed
+	return nil
+}
+
+// flush encodes the buffered bytes as one group and writes it.
+func (s *streamEncoder) flush() error {
+	width := s.widths[s.n]
+	s.out = s.out[:0]
+	for i := 0; i < width; i++ {
+		s.out = append(s.out, 0)
+	}
+	s.enc.encodeBlock(s.block[:s.n], s.out)
+	s.n = 0
+	_, err := s.w.Write(s.out)
+	return err
+}
+
+// streamDecoder implements Encoder.NewStreamingDecoder.
+type streamDecoder struct {
+	enc     *Encoder
+	r       io.Reader
+	widths  [BlockSize + 1]int
+	in      [streamChunk]byte
+	group   []byte
+	decoded []byte
+	out     []byte
+	err     error
+}
+
+// Read decodes input until it has output for p or reaches the end.
+func (d *streamDecoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.fill()
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// fill reads one chunk of input and decodes the groups it completes.
+func (d *streamDecoder) fill() {
+	d.decoded = d.decoded[:0]
+	n, err := d.r.Read(d.in[:])
+	full := d.widths[BlockSize]
+	for _, c := range d.in[:n] {
+		if c == '\n' || c == '\r' {
+			continue
+		}
+		d.group = append(d.group, d.enc.fold[c])
+		if len(d.group) == full {
+			if d.err = d.decodeGroup(BlockSize); d.err != nil {
+				return
+			}
+		}
+	}
+	switch {
+	case err == io.EOF && len(d.group) > 0:
+		d.err = d.decodeFinal()
+		if d.err == nil {
+			d.err = io.EOF
+		}
+	case err != nil:
+		d.err = err
+	}
+	d.out = d.decoded
+}
+
+// decodeFinal decodes the last, partial group.
+func (d *streamDecoder) decodeFinal() error {
+	for n := 1; n < BlockSize; n++ {
+		if d.widths[n] == len(d.group) {
+			return d.decodeGroup(n)
+		}
+	}
+	return ErrInvalidLength
+}
+
+// decodeGroup decodes the buffered group into n bytes.
+func (d *streamDecoder) decodeGroup(n int) error {
+	var block [BlockSize]byte
+	if err := d.enc.decodeBlock(d.group, block[:n]); err != nil {
+		return err
+	}
+	d.decoded = append(d.decoded, block[:n]...)
+	d.group = d.group[:0]
+	return nil
+}
+
+// digitsPerWord returns the number of digits processed per step of the
+// block arithmetic and base raised to it, keeping pow<<8 within a uint64.
+func digitsPerWord(base int) (int, uint64) {
+	k, pow := 0, uint64(1)
+	for pow*uint64(base) < 1<<55 {
+		pow *= uint64(base)
+		k++
+	}
+	return k, pow
+}
+
+// encodeBlock writes the big-endian value of src into dst as len(dst)
+// dictionary digits, by long division by a power of the base.
+func (e *Encoder) encodeBlock(src, dst []byte) {
+	base := uint64(len(e.dictionary))
+	k, pow := digitsPerWord(len(e.dictionary))
+	var num [BlockSize]byte
+	value := num[:copy(num[:], src)]
+	for i := len(dst); i > 0; {
+		for len(value) > 0 && value[0] == 0 {
+			value = value[1:]
+		}
+		var rem uint64
+		for j, b := range value {
+			cur := rem<<8 | uint64(b)
+			value[j] = byte(cur / pow)
+			rem = cur % pow
+		}
+		for d := 0; d < k && i > 0; d++ {
+			i--
+			dst[i] = e.table.Char(int(rem % base))
+			rem /= base
+		}
+	}
+}
+
+// decodeBlock reverses encodeBlock, writing the value of src into dst.
+// Returns ErrOverflow if the value does not fit in len(dst) bytes.
+func (e *Encoder) decodeBlock(src, dst []byte) error {
+	base := uint64(len(e.dictionary))
+	k, _ := digitsPerWord(len(e.dictionary))
+	for i := range dst {
+		dst[i] = 0
+	}
+	for len(src) > 0 {
+		chunk := src[:min(k, len(src))]
+		src = src[len(chunk):]
+		acc, pow := uint64(0), uint64(1)
+		for _, c := range chunk {
+			d := e.table.Digit(c)
+			if d < 0 {
+				return ErrInvalidCharacter
+			}
+			acc = acc*base + uint64(d)
+			pow *= base
+		}
+		carry := acc
+		for j := len(dst) - 1; j >= 0; j-- {
+			cur := uint64(dst[j])*pow + carry
+			dst[j] = byte(cur)
+			carry = cur >> 8
+		}
+		if carry != 0 {
+			return ErrOverflow
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package yid_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// streamEncode encodes data with enc, writing it in chunks of size bytes.
+func streamEncode(t *testing.T, enc *yid.Encoder, data []byte, size int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := enc.NewStreamingEncoder(&buf)
+	for len(data) > 0 {
+		n := min(size, len(data))
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.String()
+}
+
+// TestStream_Roundtrip tests exact round-tripping across lengths, write
+// sizes and encoders.
+func TestStream_Roundtrip(t *testing.T) {
+	encoders := map[string]*yid.Encoder{
+		"default":   yid.New(),
+		"secure":    yid.New(yid.WithSecureKey("secret")),
+		"crockford": yid.New(yid.WithAlphabet(yid.CrockfordAlphabet)),
+		"binary":    yid.New(yid.WithAlphabet("01")),
+		"base64":    yid.New(yid.WithAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_")),
+	}
+	rng := rand.New(rand.NewSource(1))
+	for name, enc := range encoders {
+		t.Run(name, func(t *testing.T) {
+			for _, length := range []int{0, 1, 2, 31, 32, 33, 63, 64, 65, 1000} {
+				data := make([]byte, length)
+				rng.Read(data)
+				if length == 2 {
+					data = []byte{0, 0}
+				}
+				for _, size := range []int{1, 7, 32, 4096} {
+					encoded := streamEncode(t, enc, data, size)
+					decoded, err := io.ReadAll(iotest.OneByteReader(enc.NewStreamingDecoder(strings.NewReader(encoded))))
+					if err != nil {
+						t.Fatalf("length %d: unexpected error: %v", length, err)
+					}
+					if !bytes.Equal(decoded, data) {
+						t.Fatalf("length %d, write size %d: round trip mismatch", length, size)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestStream_Format tests the fixed-width groups of the default alphabet.
+func TestStream_Format(t *testing.T) {
+	enc := yid.New()
+	if got := streamEncode(t, enc, []byte{0xff}, 1); got != "eh" {
+		t.Errorf("expected eh, got %q", got)
+	}
+	if got := streamEncode(t, enc, nil, 1); got != "" {
+		t.Errorf("expected empty output, got %q", got)
+	}
+	if got := streamEncode(t, enc, make([]byte, 32), 32); got != strings.Repeat("a", 43) {
+		t.Errorf("expected 43 zero digits, got %q", got)
+	}
+	data := bytes.Repeat([]byte{0xff}, 100)
+	if got := streamEncode(t, enc, data, 100); len(got) != 3*43+6 {
+		t.Errorf("expected %d characters, got %d", 3*43+6, len(got))
+	}
+}
+
+// TestStream_Keyed tests that the key changes the output and is needed to decode.
+func TestStream_Keyed(t *testing.T) {
+	data := []byte("opaque cursor: page=2&after=12345")
+	plain := streamEncode(t, yid.New(), data, len(data))
+	keyed := streamEncode(t, yid.New(yid.WithSecureKey("secret")), data, len(data))
+	if plain == keyed {
+		t.Error("expected the secure key to change the output")
+	}
+	var buf bytes.Buffer
+	w := yid.NewStreamingEncoder(&buf, yid.WithSecureKey("secret"))
+	w.Write(data)
+	w.Close()
+	if buf.String() != keyed {
+		t.Errorf("expected package function to match Encoder, got %q", buf.String())
+	}
+	decoded, err := io.ReadAll(yid.NewStreamingDecoder(strings.NewReader(keyed), yid.WithSecureKey("other")))
+	if err == nil && bytes.Equal(decoded, data) {
+		t.Error("expected a different key not to decode the data")
+	}
+}
+
+// TestStream_LineBreaks tests that line breaks in the input are ignored.
+func TestStream_LineBreaks(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10)
+	encoded := streamEncode(t, yid.New(), data, len(data))
+	wrapped := encoded[:20] + "\r\n" + encoded[20:70] + "\n" + encoded[70:]
+	decoded, err := io.ReadAll(yid.NewStreamingDecoder(strings.NewReader(wrapped)))
+	if err != nil || !bytes.Equal(decoded, data) {
+		t.Errorf("unexpected result %q, %v", decoded, err)
+	}
+}
+
+// TestStream_Errors tests the errors for malformed input.
+func TestStream_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"invalid character", "ab-", yid.ErrInvalidCharacter},
+		{"overflow", "ZZ", yid.ErrOverflow},
+		{"overflow in full block", strings.Repeat("Z", 43), yid.ErrOverflow},
+		{"invalid final length", "abcd", yid.ErrInvalidLength},
+		{"single character", "a", yid.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := io.ReadAll(yid.NewStreamingDecoder(strings.NewReader(tt.input)))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// TestStream_Closed tests Close semantics and invalid options.
+func TestStream_Closed(t *testing.T) {
+	w := yid.New().NewStreamingEncoder(io.Discard)
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("expected repeated Close to succeed, got %v", err)
+	}
+	if _, err := w.Write([]byte{1}); err == nil {
+		t.Error("expected an error writing after Close")
+	}
+
+	bad := yid.New(yid.WithAlphabet("aa"))
+	if _, err := bad.NewStreamingEncoder(io.Discard).Write([]byte{1}); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+	if _, err := io.ReadAll(bad.NewStreamingDecoder(strings.NewReader("ab"))); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
+
+// TestStream_Reader tests the decoder against the io.Reader contract.
+func TestStream_Reader(t *testing.T) {
+	data := make([]byte, 5000)
+	rand.New(rand.NewSource(2)).Read(data)
+	encoded := streamEncode(t, yid.New(), data, 100)
+	if err := iotest.TestReader(yid.NewStreamingDecoder(strings.NewReader(encoded)), data); err != nil {
+		t.Error(err)
+	}
+}
+
+// BenchmarkSuite_Stream measures streaming 4 KiB through the encoder and decoder.
+func BenchmarkSuite_Stream(b *testing.B) {
+	enc := yid.New(yid.WithSecureKey("secret"))
+	data := make([]byte, 4096)
+	rand.New(rand.NewSource(3)).Read(data)
+	var encoded bytes.Buffer
+	w := enc.NewStreamingEncoder(&encoded)
+	w.Write(data)
+	w.Close()
+	b.Run("Encode", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			w := enc.NewStreamingEncoder(io.Discard)
+			w.Write(data)
+			w.Close()
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			io.Copy(io.Discard, enc.NewStreamingDecoder(bytes.NewReader(encoded.Bytes())))
+		}
+	})
+}
@@ -0,0 +1,288 @@
+package yid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/wow-apps/youtube-id-go/internal/convert"
+)
+
+// TagName is the struct tag key that names the registered encoder of an ID field.
+//
+// Example:
+//
+//	type UserDTO struct {
+//		UserID   int64   `json:"user_id" yid:"usr"`
+//		OrgID    int64   `json:"org_id" yid:"org"`
+//		Friends  []int64 `json:"friends" yid:"usr"`
+//		Name     string  `json:"name"`
+//	}
+const TagName = "yid"
+
+// ErrUnsupportedType is returned for values that cannot be converted, such as
+// tags on non-integer fields or recursive types containing tagged fields.
+var ErrUnsupportedType = errors.New("yid: unsupported type for struct tags")
+
+// EncodeStruct copies src into dst, which must be a non-nil pointer to a
+// struct with the same field names. Fields tagged with `yid:"name"` hold
+// int64 values in src and strings in dst; they are encoded with the encoder
+// registered under name (see Register). Nested structs, pointers, slices,
+// arrays and maps are walked recursively; untagged fields are copied as is.
+func EncodeStruct(src, dst any) error {
+	return copyStruct(dst, src, convert.Encode)
+}
+
+// DecodeStruct is the reverse of EncodeStruct: it copies src into dst,
+// decoding tagged string fields into int64.
+func DecodeStruct(src, dst any) error {
+	return copyStruct(dst, src, convert.Decode)
+}
+
+// MarshalJSON returns the JSON encoding of v in which `yid`-tagged int64
+// fields are rendered as encoded strings.
+//
+// Example:
+//
+//	yid.Register("usr", yid.New())
+//	yid.MarshalJSON(UserDTO{UserID: 12345}) // -> {"user_id":"dnh",...}
+func MarshalJSON(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return json.Marshal(v)
+	}
+	shadow, err := shadowOf(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	if shadow == rv.Type() {
+		return json.Marshal(v)
+	}
+	out := reflect.New(shadow).Elem()
+	if err := convert.Copy(out, rv, convert.Encode, tagField, rv.Type().String()); err != nil {
+		return nil, fmt.Errorf("yid: %w", err)
+	}
+	return json.Marshal(out.Interface())
+}
+
+// UnmarshalJSON parses data into v, which must be a non-nil pointer,
+// decoding `yid`-tagged fields from encoded strings. The value pointed to by
+// v is replaced rather than merged.
+func UnmarshalJSON(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("yid: UnmarshalJSON requires a non-nil pointer, got %T", v)
+	}
+	shadow, err := shadowOf(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	if shadow == rv.Elem().Type() {
+		return json.Unmarshal(data, v)
+	}
+	tmp := reflect.New(shadow)
+	if err := json.Unmarshal(data, tmp.Interface()); err != nil {
+		return err
+	}
+	if err := convert.Copy(rv.Elem(), tmp.Elem(), convert.Decode, tagField, rv.Elem().Type().String()); err != nil {
+		return fmt.Errorf("yid: %w", err)
+	}
+	return nil
+}
+
+// JSON wraps a value so that encoding/json renders its `yid`-tagged fields
+// as encoded strings. For unmarshaling, V must be a pointer.
+//
+// Example:
+//
+//	json.NewEncoder(w).Encode(yid.JSON{V: dto})
+//	json.NewDecoder(r).Decode(&yid.JSON{V: &dto})
+type JSON struct {
+	V any
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(j.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j JSON) UnmarshalJSON(data []byte) error {
+	return UnmarshalJSON(data, j.V)
+}
+
+// copyStruct validates the arguments of EncodeStruct and DecodeStruct.
+func copyStruct(dst, src any, dir convert.Direction) error {
+	d := reflect.ValueOf(dst)
+	if d.Kind() != reflect.Pointer || d.IsNil() {
+		return fmt.Errorf("yid: destination must be a non-nil pointer, got %T", dst)
+	}
+	if err := convert.Copy(d.Elem(), reflect.ValueOf(src), dir, tagField, d.Elem().Type().String()); err != nil {
+		return fmt.Errorf("yid: %w", err)
+	}
+	return nil
+}
+
+// tagField returns the registered encoder named by the `yid` tag of either field.
+func tagField(dst, src reflect.StructField) (convert.Codec, error) {
+	name := tagOf(dst)
+	if name == "" {
+		name = tagOf(src)
+	}
+	if name == "" {
+		return nil, nil
+	}
+	enc, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownEncoder, name)
+	}
+	return enc, nil
+}
+
+// tagOf returns the encoder name in the `yid` tag of f, or "" if there is none.
+func tagOf(f reflect.StructField) string {
+	name := f.Tag.Get(TagName)
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// shadowTypes caches the JSON shadow type of each type.
+var shadowTypes sync.Map
+
+// shadowOf returns t with every tagged int64 replaced by string, or t itself
+// if it contains no tagged fields.
+func shadowOf(t reflect.Type) (reflect.Type, error) {
+	if cached, ok := shadowTypes.Load(t); ok {
+		return cached.(reflect.Type), nil
+	}
+	shadow, err := buildShadow(t, make(map[reflect.Type]bool))
+	if err != nil {
+		return nil, err
+	}
+	shadowTypes.Store(t, shadow)
+	return shadow, nil
+}
+
+// buildShadow builds the shadow of t. building holds the struct types on the current path.
+func buildShadow(t reflect.Type, building map[reflect.Type]bool) (reflect.Type, error) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem, err := buildShadow(t.Elem(), building)
+		if err != nil || elem == t.Elem() {
+			return t, err
+		}
+		return reflect.PointerTo(elem), nil
+	case reflect.Slice:
+		elem, err := buildShadow(t.Elem(), building)
+		if err != nil || elem == t.Elem() {
+			return t, err
+		}
+		return reflect.SliceOf(elem), nil
+	case reflect.Array:
+		elem, err := buildShadow(t.Elem(), building)
+		if err != nil || elem == t.Elem() {
+			return t, err
+		}
+		return reflect.ArrayOf(t.Len(), elem), nil
+	case reflect.Map:
+		elem, err := buildShadow(t.Elem(), building)
+		if err != nil || elem == t.Elem() {
+			return t, err
+		}
+		return reflect.MapOf(t.Key(), elem), nil
+	case reflect.Struct:
+		return buildStructShadow(t, building)
+	default:
+		return t, nil
+	}
+}
+
+// buildStructShadow builds the shadow of struct type t.
+func buildStructShadow(t reflect.Type, building map[reflect.Type]bool) (reflect.Type, error) {
+	if building[t] {
+		// reflect.StructOf cannot build recursive types, so a recursive
+		// type is only supported if it has no tagged fields.
+		if hasTags(t, make(map[reflect.Type]bool)) {
+			return nil, fmt.Errorf("%w: recursive type %s", ErrUnsupportedType, t)
+		}
+		return t, nil
+	}
+	building[t] = true
+	defer delete(building, t)
+
+	changed := false
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		var err error
+		ft := f.Type
+		if tagOf(f) != "" {
+			ft, err = taggedShadow(f.Type)
+		} else {
+			ft, err = buildShadow(f.Type, building)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w (field %s.%s)", err, t, f.Name)
+		}
+		if ft != f.Type {
+			changed = true
+		}
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: ft, Tag: f.Tag, Anonymous: f.Anonymous})
+	}
+	if !changed {
+		return t, nil
+	}
+	return reflect.StructOf(fields), nil
+}
+
+// taggedShadow replaces the int64 in a tagged field type with string.
+func taggedShadow(t reflect.Type) (reflect.Type, error) {
+	switch t.Kind() {
+	case reflect.Int64:
+		return reflect.TypeOf(""), nil
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		elem, err := taggedShadow(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		switch t.Kind() {
+		case reflect.Pointer:
+			return reflect.PointerTo(elem), nil
+		case reflect.Slice:
+			return reflect.SliceOf(elem), nil
+		case reflect.Array:
+			return reflect.ArrayOf(t.Len(), elem), nil
+		default:
+			return reflect.MapOf(t.Key(), elem), nil
+		}
+	default:
+		return nil, fmt.Errorf("%w: tagged %s is not int64", ErrUnsupportedType, t)
+	}
+}
+
+// hasTags reports whether t contains a tagged field anywhere.
+func hasTags(t reflect.Type, seen map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return hasTags(t.Elem(), seen)
+	case reflect.Struct:
+		if seen[t] {
+			return false
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.IsExported() && (tagOf(f) != "" || hasTags(f.Type, seen)) {
+				return true
+			}
+		}
+	}
+	return false
+}
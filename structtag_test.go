@@ -0,0 +1,251 @@
+package yid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+func init() {
+	yid.Register("usr", yid.New())
+	yid.Register("org", yid.New(yid.WithSecureKey("orgs")))
+}
+
+type Audit struct {
+	CreatedBy int64 `json:"created_by" yid:"usr"`
+}
+
+type OrgDTO struct {
+	OrgID int64 `json:"org_id" yid:"org"`
+}
+
+type UserDTO struct {
+	Audit
+	UserID    int64            `json:"user_id" yid:"usr"`
+	ParentID  *int64           `json:"parent_id,omitempty" yid:"usr"`
+	FriendIDs []int64          `json:"friend_ids" yid:"usr"`
+	Org       OrgDTO           `json:"org"`
+	Teams     []*OrgDTO        `json:"teams"`
+	Scores    map[string]int64 `json:"scores"`
+	Name      string           `json:"name"`
+	Raw       int64            `json:"raw" yid:"-"`
+	internal  int64
+}
+
+type PublicUserDTO struct {
+	UserID    string
+	ParentID  *string
+	FriendIDs []string
+	Org       struct{ OrgID string }
+	Name      string
+}
+
+// encode returns the registered encoder's raw output for n.
+func encode(t *testing.T, name string, n int64) string {
+	t.Helper()
+	enc, ok := yid.Lookup(name)
+	if !ok {
+		t.Fatalf("encoder %q not registered", name)
+	}
+	s, err := enc.EncodeRaw(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return s
+}
+
+// TestMarshalJSON_TaggedFields tests that tagged fields render as encoded strings.
+func TestMarshalJSON_TaggedFields(t *testing.T) {
+	parent := int64(1)
+	dto := UserDTO{
+		Audit:     Audit{CreatedBy: 62},
+		UserID:    12345,
+		ParentID:  &parent,
+		FriendIDs: []int64{2, 3},
+		Org:       OrgDTO{OrgID: 7},
+		Teams:     []*OrgDTO{{OrgID: 8}, nil},
+		Scores:    map[string]int64{"a": 1},
+		Name:      "Ada",
+		Raw:       5,
+	}
+	data, err := yid.MarshalJSON(dto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]any{
+		"created_by": "ba",
+		"user_id":    "dnh",
+		"parent_id":  "b",
+		"friend_ids": []any{"c", "d"},
+		"org":        map[string]any{"org_id": encode(t, "org", 7)},
+		"teams":      []any{map[string]any{"org_id": encode(t, "org", 8)}, nil},
+		"scores":     map[string]any{"a": float64(1)},
+		"name":       "Ada",
+		"raw":        float64(5),
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+// TestUnmarshalJSON_Roundtrip tests that UnmarshalJSON reverses MarshalJSON.
+func TestUnmarshalJSON_Roundtrip(t *testing.T) {
+	parent := int64(99)
+	original := UserDTO{
+		Audit:     Audit{CreatedBy: 1},
+		UserID:    12345,
+		ParentID:  &parent,
+		FriendIDs: []int64{4},
+		Org:       OrgDTO{OrgID: 7},
+		Teams:     []*OrgDTO{{OrgID: 8}},
+		Name:      "Ada",
+	}
+	data, err := yid.MarshalJSON(&original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded UserDTO
+	if err := yid.UnmarshalJSON(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("expected %+v, got %+v", original, decoded)
+	}
+}
+
+// TestJSON_Wrapper tests the json.Marshaler wrapper.
+func TestJSON_Wrapper(t *testing.T) {
+	data, err := json.Marshal(map[string]any{"user": yid.JSON{V: OrgDTO{OrgID: 0}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"user":{"org_id":"` + encode(t, "org", 0) + `"}}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	var dto OrgDTO
+	if err := json.Unmarshal([]byte(`{"org_id":"`+encode(t, "org", 42)+`"}`), &yid.JSON{V: &dto}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dto.OrgID != 42 {
+		t.Errorf("expected 42, got %d", dto.OrgID)
+	}
+}
+
+// TestJSON_UntaggedTypes tests that values without tags use encoding/json directly.
+func TestJSON_UntaggedTypes(t *testing.T) {
+	data, err := yid.MarshalJSON(struct{ N int64 }{5})
+	if err != nil || string(data) != `{"N":5}` {
+		t.Errorf("unexpected result: %s (%v)", data, err)
+	}
+	if data, err := yid.MarshalJSON(nil); err != nil || string(data) != "null" {
+		t.Errorf("unexpected result for nil: %s (%v)", data, err)
+	}
+	var n int64
+	if err := yid.UnmarshalJSON([]byte("7"), &n); err != nil || n != 7 {
+		t.Errorf("unexpected result: %d (%v)", n, err)
+	}
+}
+
+// TestUnmarshalJSON_Errors tests invalid targets, JSON and IDs.
+func TestUnmarshalJSON_Errors(t *testing.T) {
+	var dto OrgDTO
+	if err := yid.UnmarshalJSON([]byte(`{}`), dto); err == nil {
+		t.Error("expected error for non-pointer")
+	}
+	if err := yid.UnmarshalJSON([]byte(`{`), &dto); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+	if err := yid.UnmarshalJSON([]byte(`{"org_id":"a-b"}`), &dto); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter, got %v", err)
+	}
+	if err := yid.UnmarshalJSON([]byte(`{"org_id":""}`), &dto); err != nil || dto.OrgID != 0 {
+		t.Errorf("expected empty ID to decode to 0, got %d (%v)", dto.OrgID, err)
+	}
+}
+
+// TestMarshalJSON_Errors tests unsupported types, unknown encoders and encode failures.
+func TestMarshalJSON_Errors(t *testing.T) {
+	type badTag struct {
+		Name string `yid:"usr"`
+	}
+	if _, err := yid.MarshalJSON(badTag{}); !errors.Is(err, yid.ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+	var bad badTag
+	if err := yid.UnmarshalJSON([]byte(`{}`), &bad); !errors.Is(err, yid.ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType from UnmarshalJSON, got %v", err)
+	}
+
+	type unknown struct {
+		ID int64 `yid:"missing"`
+	}
+	if _, err := yid.MarshalJSON(unknown{}); !errors.Is(err, yid.ErrUnknownEncoder) {
+		t.Errorf("expected ErrUnknownEncoder, got %v", err)
+	}
+
+	if _, err := yid.MarshalJSON(OrgDTO{OrgID: -1}); !errors.Is(err, yid.ErrNegativeNumber) {
+		t.Errorf("expected ErrNegativeNumber, got %v", err)
+	}
+}
+
+type treeNode struct {
+	ID       int64       `yid:"usr"`
+	Children []*treeNode `json:"children"`
+}
+
+type plainTree struct {
+	Children []*plainTree
+}
+
+// TestMarshalJSON_RecursiveTypes tests that recursive types are only rejected when tagged.
+func TestMarshalJSON_RecursiveTypes(t *testing.T) {
+	if _, err := yid.MarshalJSON(treeNode{}); !errors.Is(err, yid.ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+	data, err := yid.MarshalJSON(plainTree{Children: []*plainTree{{}}})
+	if err != nil || !strings.Contains(string(data), "Children") {
+		t.Errorf("unexpected result: %s (%v)", data, err)
+	}
+}
+
+// TestEncodeStruct tests copying into a struct with string ID fields and back.
+func TestEncodeStruct(t *testing.T) {
+	parent := int64(3)
+	src := UserDTO{UserID: 12345, ParentID: &parent, FriendIDs: []int64{1}, Org: OrgDTO{OrgID: 7}, Name: "Ada"}
+	var dst PublicUserDTO
+	if err := yid.EncodeStruct(src, &dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.UserID != "dnh" || *dst.ParentID != "d" || dst.FriendIDs[0] != "b" || dst.Name != "Ada" {
+		t.Errorf("unexpected result: %+v", dst)
+	}
+	if dst.Org.OrgID != encode(t, "org", 7) {
+		t.Errorf("expected nested org ID to be encoded, got %s", dst.Org.OrgID)
+	}
+
+	var back UserDTO
+	if err := yid.DecodeStruct(dst, &back); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if back.UserID != 12345 || *back.ParentID != 3 || back.FriendIDs[0] != 1 || back.Org.OrgID != 7 {
+		t.Errorf("unexpected result: %+v", back)
+	}
+
+	if err := yid.EncodeStruct(src, dst); err == nil {
+		t.Error("expected error for non-pointer destination")
+	}
+	if err := yid.DecodeStruct(PublicUserDTO{UserID: "!"}, &back); !errors.Is(err, yid.ErrInvalidCharacter) {
+		t.Errorf("expected ErrInvalidCharacter, got %v", err)
+	}
+}
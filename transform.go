@@ -1,6 +1,9 @@
 package yid
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 // Transform specifies case transformation for encoding output.
 type Transform int
@@ -25,3 +28,17 @@ func applyCaseTransform(value string, t Transform) string {
 		return value
 	}
 }
+
+// String returns the lower-case name of the transformation.
+func (t Transform) String() string {
+	switch t {
+	case TransformNone:
+		return "none"
+	case TransformUpper:
+		return "upper"
+	case TransformLower:
+		return "lower"
+	default:
+		return "Transform(" + strconv.Itoa(int(t)) + ")"
+	}
+}
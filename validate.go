@@ -0,0 +1,82 @@
+package yid
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNotCanonical is returned by Validate for strings that Decode accepts
+// but Encode never produces, such as IDs with leading zero characters, and
+// by Decode for strings whose value is below the padUp minimum or, with
+// WithSignature, that have leading zero characters.
+var ErrNotCanonical = errors.New("yid: not in canonical form")
+
+// Valid reports whether Validate(s) returns nil.
+//
+// Example:
+//
+//	if !enc.Valid(r.PathValue("id")) {
+//		http.NotFound(w, r)
+//		return
+//	}
+func (e *Encoder) Valid(s string) bool {
+	return e.Validate(s) == nil
+}
+
+// Validate checks that s, after the normalization Decode applies (see
+// WithGrouping and WithAlphabet), is exactly what EncodeRaw returns for some
+// number: prefix, signature length, dictionary membership (using the lookup
+// table built by New), int64 overflow, canonical form and, for signed
+// encoders, the signature. It makes Decode's checks in Decode's order and
+// returns the same errors, plus ErrNotCanonical for strings that Decode
+// accepts but Encode never produces (the empty string, leading zero
+// characters, or values below the padUp minimum). Validate does not notify
+// observers.
+//
+// Without a signature, Validate skips the work of building the result and is
+// cheaper than Decode. With WithSignature both are dominated by the HMAC and
+// cost about the same once the input passes the cheap checks.
+func (e *Encoder) Validate(s string) error {
+	if e.err != nil {
+		return e.err
+	}
+	body, err := e.trimPrefix(s)
+	if err != nil {
+		return err
+	}
+	body = e.normalize(body)
+
+	payload, sig := body, ""
+	if e.signed() {
+		if payload, sig, err = e.split(body); err != nil {
+			return err
+		}
+		if err := e.canonical(payload); err != nil {
+			return err
+		}
+	}
+	if e.sortable && len(payload) != SortableLength {
+		return ErrInvalidLength
+	}
+	base := int64(len(e.dictionary))
+	var value int64
+	for i := 0; i < len(payload); i++ {
+		d := int64(e.table.Digit(payload[i]))
+		if d < 0 {
+			return ErrInvalidCharacter
+		}
+		if value > (math.MaxInt64-d)/base {
+			return ErrOverflow
+		}
+		value = value*base + d
+	}
+	offset, _ := e.valueBounds()
+	if payload == "" || value < offset || e.canonical(payload) != nil {
+		return ErrNotCanonical
+	}
+
+	if e.signed() {
+		return e.verify(sig, signDomainID, value-offset)
+	}
+	return nil
+}
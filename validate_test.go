@@ -0,0 +1,147 @@
+package yid_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	yid "github.com/wow-apps/youtube-id-go"
+)
+
+// TestValid_MatchesCanonical tests that Valid accepts exactly the strings EncodeRaw produces.
+func TestValid_MatchesCanonical(t *testing.T) {
+	encoders := map[string][]yid.Option{
+		"signed":        {yid.WithSignature([]byte("hmac-secret"), 4), yid.WithSecureKey("secret")},
+		"signed prefix": {yid.WithSignature([]byte("hmac-secret"), 2), yid.WithPrefix("usr_"), yid.WithPadUp(3)},
+	}
+	for name, opts := range patternEncoders {
+		encoders[name] = opts
+	}
+	for name, opts := range encoders {
+		t.Run(name, func(t *testing.T) {
+			enc := yid.New(opts...)
+			rng := rand.New(rand.NewSource(4))
+			for _, s := range candidates(enc, rng) {
+				if got, want := enc.Valid(s), canonical(enc, s); got != want {
+					t.Errorf("Valid(%q) = %t, want %t (Validate: %v)", s, got, want, enc.Validate(s))
+				}
+			}
+		})
+	}
+}
+
+// TestValidate_Errors tests the error returned for each kind of invalid input.
+func TestValidate_Errors(t *testing.T) {
+	signed := yid.New(yid.WithSignature([]byte("hmac-secret"), 4))
+	valid, _ := signed.EncodeRaw(12345)
+
+	tests := []struct {
+		name     string
+		enc      *yid.Encoder
+		input    string
+		expected error
+	}{
+		{"valid", yid.New(), "dnh", nil},
+		{"empty", yid.New(), "", yid.ErrNotCanonical},
+		{"too long", yid.New(), "bbbbbbbbbbbb", yid.ErrOverflow},
+		{"long with leading zeros", yid.New(), "aa02mUFeykl7", yid.ErrNotCanonical},
+		{"leading zero and invalid character", yid.New(), "adn-", yid.ErrInvalidCharacter},
+		{"invalid character", yid.New(), "dn-", yid.ErrInvalidCharacter},
+		{"overflow", yid.New(), "ZZZZZZZZZZZ", yid.ErrOverflow},
+		{"leading zero", yid.New(), "adnh", yid.ErrNotCanonical},
+		{"below padUp", yid.New(yid.WithPadUp(3)), "abc", yid.ErrNotCanonical},
+		{"short for padUp", yid.New(yid.WithPadUp(3)), "bc", yid.ErrNotCanonical},
+		{"sortable short", yid.New(yid.WithSortable()), "3D7", yid.ErrInvalidLength},
+		{"sortable valid", yid.New(yid.WithSortable()), "000000003D7", nil},
+		{"missing prefix", yid.New(yid.WithPrefix("usr_")), "dnh", yid.ErrInvalidPrefix},
+		{"signed valid", signed, valid, nil},
+		{"bad signature", signed, "dnhaaaa", yid.ErrInvalidSignature},
+		{"missing signature", signed, "dnh", yid.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.enc.Validate(tt.input); !errors.Is(err, tt.expected) {
+				t.Errorf("Validate(%q): expected %v, got %v", tt.input, tt.expected, err)
+			}
+		})
+	}
+}
+
+// TestValidate_MatchesDecode tests that Validate returns Decode's error for
+// every input Decode rejects, and nil or ErrNotCanonical otherwise.
+func TestValidate_MatchesDecode(t *testing.T) {
+	encoders := map[string][]yid.Option{
+		"signed":        {yid.WithSignature([]byte("hmac-secret"), 4), yid.WithSecureKey("secret")},
+		"signed prefix": {yid.WithSignature([]byte("hmac-secret"), 2), yid.WithPrefix("usr_"), yid.WithPadUp(3)},
+	}
+	for name, opts := range patternEncoders {
+		encoders[name] = opts
+	}
+	extra := []string{"", "a", "aa02mUFeykl7", "aaaaaaaaaaaaaaaaaaaa", "adn-", "bbbbbbbbbbbb"}
+	for name, opts := range encoders {
+		t.Run(name, func(t *testing.T) {
+			enc := yid.New(opts...)
+			rng := rand.New(rand.NewSource(5))
+			for _, s := range append(candidates(enc, rng), extra...) {
+				_, decodeErr := enc.Decode(s)
+				err := enc.Validate(s)
+				switch {
+				case decodeErr != nil && !errors.Is(err, decodeErr):
+					t.Errorf("Validate(%q) = %v, want Decode's error %v", s, err, decodeErr)
+				case decodeErr == nil && err != nil && !errors.Is(err, yid.ErrNotCanonical):
+					t.Errorf("Validate(%q) = %v, want nil or ErrNotCanonical", s, err)
+				}
+			}
+		})
+	}
+}
+
+// TestValidate_NoObserver tests that validation is not reported as a decode.
+func TestValidate_NoObserver(t *testing.T) {
+	rec := &recorder{}
+	yid.New(yid.WithObserver(rec)).Valid("dnh")
+	if len(rec.decodes) != 0 {
+		t.Errorf("expected no events, got %d", len(rec.decodes))
+	}
+}
+
+// benchmarkInputs is a mix of valid IDs and junk as seen at an API edge.
+var benchmarkInputs = []string{"dnh", "kZviNa8fiMh", "bbbbbb", "dn-", "wp-admin.php", "ZZZZZZZZZZZ", "", "aaaaaaaaaaaaaaaaaaaa"}
+
+// BenchmarkValid measures Valid on mixed input.
+func BenchmarkValid(b *testing.B) {
+	enc := yid.New(yid.WithSecureKey("secret"))
+	for i := 0; i < b.N; i++ {
+		enc.Valid(benchmarkInputs[i%len(benchmarkInputs)])
+	}
+}
+
+// BenchmarkDecodeDiscard measures the Decode-and-discard approach Valid replaces.
+func BenchmarkDecodeDiscard(b *testing.B) {
+	enc := yid.New(yid.WithSecureKey("secret"))
+	for i := 0; i < b.N; i++ {
+		_, err := enc.Decode(benchmarkInputs[i%len(benchmarkInputs)])
+		_ = err == nil
+	}
+}
+
+// BenchmarkValid_Signed measures Valid on signed IDs, dominated by the HMAC.
+func BenchmarkValid_Signed(b *testing.B) {
+	enc := yid.New(yid.WithSignature([]byte("hmac-secret"), 6))
+	id, _ := enc.EncodeRaw(12345)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		enc.Valid(id)
+	}
+}
+
+// BenchmarkDecodeDiscard_Signed measures Decode on signed IDs, which costs about
+// the same as BenchmarkValid_Signed.
+func BenchmarkDecodeDiscard_Signed(b *testing.B) {
+	enc := yid.New(yid.WithSignature([]byte("hmac-secret"), 6))
+	id, _ := enc.EncodeRaw(12345)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		enc.Decode(id)
+	}
+}
@@ -10,16 +10,35 @@
 //	fmt.Println(decoded) // -> 12345
 package yid
 
-import "github.com/wow-apps/youtube-id-go/internal/base62"
+import (
+	"fmt"
+	"io"
+
+	"github.com/wow-apps/youtube-id-go/internal/base62"
+)
 
 // Version is the current version of the package.
 const Version = "1.0.0"
 
 // config holds encoding/decoding configuration.
 type config struct {
-	padUp     int
-	secureKey string
-	transform Transform
+	padUp      int
+	secureKey  string
+	keyed      bool
+	keyErr     error
+	transform  Transform
+	sortable   bool
+	randSource io.Reader
+	signKey    []byte
+	signChars  int
+	name       string
+	observers  []Observer
+	prefix     string
+	alphabet   string
+	groupSize  int
+	groupSep   string
+	safe       bool
+	lookalikes bool
 }
 
 // Option configures encoding/decoding behavior.
@@ -28,6 +47,10 @@ type Option func(*config)
 // MaxPadUp is the maximum safe padUp value to avoid integer overflow.
 const MaxPadUp = 11
 
+// SortableLength is the fixed length of raw output in sortable mode.
+// It is the length of the longest encoded int64.
+const SortableLength = base62.MaxLen
+
 // WithPadUp sets the padding value for minimum output length.
 // Negative values are treated as 0. Values exceeding MaxPadUp (11) are clamped.
 func WithPadUp(padUp int) Option {
@@ -45,6 +68,8 @@ func WithPadUp(padUp int) Option {
 func WithSecureKey(key string) Option {
 	return func(c *config) {
 		c.secureKey = key
+		c.keyed = key != ""
+		c.keyErr = nil
 	}
 }
 
@@ -55,16 +80,72 @@ func WithTransform(t Transform) Option {
 	}
 }
 
+// WithSortable enables order-preserving output. Numbers are encoded with an
+// ASCII-ordered dictionary (0-9, A-Z, a-z) and left-padded to SortableLength,
+// so that a < b implies Encode(a) < Encode(b) byte-wise. This allows range
+// scans over encoded IDs in key-value stores.
+//
+// A secure key would scramble the order, so combining it with WithSortable
+// makes every method of the Encoder return an error wrapping
+// ErrInvalidOption. Case transformations also break the ordering; use
+// TransformNone or EncodeRaw.
+func WithSortable() Option {
+	return func(c *config) {
+		c.sortable = true
+	}
+}
+
 // defaultConfig returns the default configuration.
 func defaultConfig() config {
 	return config{
-		padUp:     0,
-		secureKey: "",
-		transform: TransformNone,
+		padUp:      0,
+		secureKey:  "",
+		keyed:      false,
+		keyErr:     nil,
+		transform:  TransformNone,
+		sortable:   false,
+		randSource: nil,
+		signKey:    nil,
+		signChars:  0,
+		name:       "",
+		observers:  nil,
+		prefix:     "",
+		alphabet:   "",
+		groupSize:  0,
+		groupSep:   "",
+		safe:       false,
+		lookalikes: false,
+	}
+}
+
+// checkSortable reports whether sortable mode is combined with a secure key.
+func (c *config) checkSortable() error {
+	if c.sortable && c.keyed {
+		return fmt.Errorf("%w: a secure key cannot be used with WithSortable", ErrInvalidOption)
+	}
+	return nil
+}
+
+// dictionary returns the dictionary selected by the configuration: the
+// sortable dictionary in sortable mode, otherwise the alphabet (default
+// DefaultAlphabet), shuffled if a secure key is set.
+func (c *config) dictionary() string {
+	if c.sortable {
+		return base62.SortableDictionary
 	}
+	alphabet := c.alphabet
+	if alphabet == "" {
+		alphabet = base62.Dictionary
+	}
+	if c.secureKey != "" {
+		return base62.ShuffleDictionary(alphabet, c.secureKey)
+	}
+	return alphabet
 }
 
 // ToAlphanumeric converts a number to a short alphanumeric string.
+// Encoders for recently used option sets are cached, so that repeated calls
+// do not rebuild the dictionary (see New for the uncached path).
 //
 // Example:
 //
@@ -72,47 +153,19 @@ func defaultConfig() config {
 //	yid.ToAlphanumeric(12345, yid.WithSecureKey("secret"))       // -> obfuscated
 //	yid.ToAlphanumeric(12345, yid.WithTransform(yid.TransformUpper)) // -> "DNH"
 func ToAlphanumeric(number int64, opts ...Option) (string, error) {
-	if number < 0 {
-		return "", ErrNegativeNumber
-	}
-
-	cfg := defaultConfig()
-	for _, opt := range opts {
-		opt(&cfg)
-	}
-
-	dictionary := base62.Dictionary
-	if cfg.secureKey != "" {
-		dictionary = base62.SecureDictionary(cfg.secureKey)
-	}
-
-	result := base62.Encode(number, dictionary, cfg.padUp)
-	return applyCaseTransform(result, cfg.transform), nil
+	return cachedEncoder(opts).Encode(number)
 }
 
 // ToNumeric converts an alphanumeric string back to a number.
 // The input must be the raw (untransformed) value. If you encoded with
 // WithTransform, you must decode using the original untransformed value.
 // The WithTransform option is ignored by this function.
+// Encoders are cached like in ToAlphanumeric.
 //
 // Example:
 //
 //	yid.ToNumeric("dnh")                               // -> 12345
 //	yid.ToNumeric(encoded, yid.WithSecureKey("secret")) // with same key used for encoding
 func ToNumeric(alphanumeric string, opts ...Option) (int64, error) {
-	cfg := defaultConfig()
-	for _, opt := range opts {
-		opt(&cfg)
-	}
-
-	dictionary := base62.Dictionary
-	if cfg.secureKey != "" {
-		dictionary = base62.SecureDictionary(cfg.secureKey)
-	}
-
-	result, err := base62.Decode(alphanumeric, dictionary, cfg.padUp)
-	if err != nil {
-		return 0, ErrInvalidCharacter
-	}
-	return result, nil
+	return cachedEncoder(opts).Decode(alphanumeric)
 }
@@ -1,6 +1,8 @@
 package yid_test
 
 import (
+	"errors"
+	"math"
 	"strings"
 	"testing"
 
@@ -703,6 +705,20 @@ func TestEdgeCases_EmptyStringDecode(t *testing.T) {
 	}
 }
 
+// TestEdgeCases_BelowPadUpMinimum tests that strings decoding below the padUp
+// offset fail instead of returning negative numbers.
+func TestEdgeCases_BelowPadUpMinimum(t *testing.T) {
+	for _, input := range []string{"b", "ab", "aab"} {
+		n, err := yid.ToNumeric(input, yid.WithPadUp(3))
+		if !errors.Is(err, yid.ErrNotCanonical) {
+			t.Errorf("ToNumeric(%q) = %d, %v, want ErrNotCanonical", input, n, err)
+		}
+	}
+	if n, err := yid.ToNumeric("baa", yid.WithPadUp(3)); err != nil || n != 0 {
+		t.Errorf("expected 0, got %d, %v", n, err)
+	}
+}
+
 // TestEdgeCases_DecodeTransformedString tests that transformed strings cannot be decoded directly.
 // ToNumeric expects raw (untransformed) values; uppercase/lowercase transformed output will fail.
 func TestEdgeCases_DecodeTransformedString(t *testing.T) {
@@ -751,3 +767,117 @@ func TestEdgeCases_DecodeRawAfterTransform(t *testing.T) {
 		t.Errorf("expected 12345, got %d", decoded)
 	}
 }
+
+// sortableBoundaries returns numbers on both sides of every output length boundary.
+func sortableBoundaries() []int64 {
+	numbers := []int64{0, 1, 2}
+	for p := int64(62); p > 0 && p < math.MaxInt64/62; p *= 62 {
+		numbers = append(numbers, p-1, p, p+1)
+	}
+	return append(numbers, math.MaxInt64/62, math.MaxInt64-1, math.MaxInt64)
+}
+
+// TestSortable_Monotonic tests that encoded output sorts in numeric order across length boundaries.
+func TestSortable_Monotonic(t *testing.T) {
+	for _, padUp := range []int{0, 3} {
+		enc := yid.New(yid.WithSortable(), yid.WithPadUp(padUp))
+		var prev string
+		for i, num := range sortableBoundaries() {
+			encoded, err := enc.Encode(num)
+			if errors.Is(err, yid.ErrOverflow) {
+				continue
+			}
+			if err != nil {
+				t.Fatalf("unexpected error for %d: %v", num, err)
+			}
+			if len(encoded) != yid.SortableLength {
+				t.Errorf("length of '%s' = %d, want %d", encoded, len(encoded), yid.SortableLength)
+			}
+			if i > 0 && prev >= encoded {
+				t.Errorf("padUp=%d: expected '%s' < '%s' for %d", padUp, prev, encoded, num)
+			}
+			prev = encoded
+		}
+	}
+}
+
+// TestSortable_Roundtrip tests that sortable output decodes back to the original number.
+func TestSortable_Roundtrip(t *testing.T) {
+	for _, num := range sortableBoundaries() {
+		encoded, err := yid.ToAlphanumeric(num, yid.WithSortable())
+		if err != nil {
+			t.Fatalf("unexpected error for %d: %v", num, err)
+		}
+		decoded, err := yid.ToNumeric(encoded, yid.WithSortable())
+		if err != nil {
+			t.Fatalf("unexpected error decoding '%s': %v", encoded, err)
+		}
+		if decoded != num {
+			t.Errorf("roundtrip failed: %d -> %s -> %d", num, encoded, decoded)
+		}
+	}
+}
+
+// TestSortable_Values tests known sortable outputs.
+func TestSortable_Values(t *testing.T) {
+	tests := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "00000000000"},
+		{61, "0000000000z"},
+		{62, "00000000010"},
+		{12345, "000000003D7"},
+	}
+	for _, tt := range tests {
+		result, err := yid.ToAlphanumeric(tt.input, yid.WithSortable())
+		if err != nil {
+			t.Fatalf("unexpected error for %d: %v", tt.input, err)
+		}
+		if result != tt.expected {
+			t.Errorf("for %d: expected '%s', got '%s'", tt.input, tt.expected, result)
+		}
+	}
+}
+
+// TestSortable_RejectsSecureKey tests that a secure key, which would scramble
+// the order, makes every method fail.
+func TestSortable_RejectsSecureKey(t *testing.T) {
+	if _, err := yid.ToAlphanumeric(12345, yid.WithSortable(), yid.WithSecureKey("secret")); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("ToAlphanumeric: expected ErrInvalidOption, got %v", err)
+	}
+	enc := yid.New(yid.WithSecureKey("secret"), yid.WithSortable())
+	if _, err := enc.Encode(12345); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("Encode: expected ErrInvalidOption, got %v", err)
+	}
+	if _, err := enc.Decode("000000003D7"); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("Decode: expected ErrInvalidOption, got %v", err)
+	}
+	if err := enc.Validate("000000003D7"); !errors.Is(err, yid.ErrInvalidOption) {
+		t.Errorf("Validate: expected ErrInvalidOption, got %v", err)
+	}
+	if _, err := yid.ToAlphanumeric(12345, yid.WithSortable(), yid.WithSecureKey("")); err != nil {
+		t.Errorf("expected an empty secure key to be allowed, got %v", err)
+	}
+}
+
+// TestSortable_DecodeInvalidLength tests that sortable decoding requires the fixed length.
+func TestSortable_DecodeInvalidLength(t *testing.T) {
+	enc := yid.New(yid.WithSortable())
+	for _, input := range []string{"", "3D7", "0000000003D7"} {
+		if _, err := enc.Decode(input); !errors.Is(err, yid.ErrInvalidLength) {
+			t.Errorf("Decode('%s'): expected ErrInvalidLength, got %v", input, err)
+		}
+	}
+}
+
+// TestEncoder_Overflow tests that numbers too large for padUp return ErrOverflow.
+func TestEncoder_Overflow(t *testing.T) {
+	enc := yid.New(yid.WithPadUp(yid.MaxPadUp))
+	if _, err := enc.Encode(math.MaxInt64); !errors.Is(err, yid.ErrOverflow) {
+		t.Errorf("expected ErrOverflow from Encode, got %v", err)
+	}
+	if _, err := enc.Decode("ZZZZZZZZZZZZ"); !errors.Is(err, yid.ErrOverflow) {
+		t.Errorf("expected ErrOverflow from Decode, got %v", err)
+	}
+}